@@ -37,7 +37,7 @@ func newRootCmd() *cobra.Command {
 
 			clientID, _ := cmd.Flags().GetString("client-id")
 
-			mgr, err := setupLocationManager(cfg, false, false)
+			mgr, err := setupLocationManager(cfg, nil)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error: %v\n", err)
 				os.Exit(1)
@@ -54,6 +54,7 @@ func newRootCmd() *cobra.Command {
 	cmd.AddCommand(newUICmd())
 	cmd.AddCommand(newLocationsCmd())
 	cmd.AddCommand(newSessionsCmd())
+	cmd.AddCommand(newTrustCmd())
 
 	return cmd
 }
@@ -3,6 +3,7 @@ package cli
 import (
 	"atelier-go/internal/config"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/output"
 	"fmt"
 	"os"
 
@@ -10,39 +11,43 @@ import (
 )
 
 func newLocationsCmd() *cobra.Command {
-	var listProjectsOnly bool
-	var listZoxideOnly bool
+	var sources []string
+	var outputOpts output.Options
 
 	cmd := &cobra.Command{
 		Use:   "locations",
 		Short: "List available projects and directories",
 		Run: func(cmd *cobra.Command, args []string) {
+			if err := outputOpts.Validate(); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
 			cfg, err := config.LoadConfig()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
 				os.Exit(1)
 			}
 
-			mgr, err := setupLocationManager(cfg, listProjectsOnly, listZoxideOnly)
+			mgr, err := setupLocationManager(cfg, sources)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error building manager: %v\n", err)
 				os.Exit(1)
 			}
 
-			locs, err := mgr.GetAll(cmd.Context())
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "error fetching locations: %v\n", err)
-				os.Exit(1)
+			locs, failed := mgr.GetAll(cmd.Context())
+			for _, f := range failed {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", f)
 			}
 
-			if err := locations.PrintTable(os.Stdout, locs); err != nil {
+			if err := output.Write(os.Stdout, outputOpts, locations.Renderer, locs); err != nil {
 				fmt.Fprintf(os.Stderr, "error printing locations: %v\n", err)
 			}
 		},
 	}
 
-	cmd.Flags().BoolVarP(&listProjectsOnly, "projects", "p", false, "List only configured projects")
-	cmd.Flags().BoolVarP(&listZoxideOnly, "zoxide", "z", false, "List only zoxide directories")
+	addSourceFlag(cmd, &sources)
+	addOutputFlags(cmd, &outputOpts)
 
 	return cmd
 }
@@ -0,0 +1,13 @@
+package cli
+
+import (
+	"atelier-go/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+// addOutputFlags registers the shared --output and --format flags.
+func addOutputFlags(cmd *cobra.Command, opts *output.Options) {
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", output.FormatTable, "Output format: table, json, yaml or tsv")
+	cmd.Flags().StringVar(&opts.Template, "format", "", "Go template applied to each item (e.g. '{{.Name}}')")
+}
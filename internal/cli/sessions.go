@@ -4,12 +4,17 @@ import (
 	"atelier-go/internal/config"
 	"atelier-go/internal/env"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/output"
 	"atelier-go/internal/sessions"
+	"atelier-go/internal/terminal"
 	"atelier-go/internal/utils"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -21,8 +26,11 @@ func newSessionsCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newSessionsAttachCmd())
+	cmd.AddCommand(newSessionsStartCmd())
 	cmd.AddCommand(newSessionsKillCmd())
 	cmd.AddCommand(newSessionsListCmd())
+	cmd.AddCommand(newSessionsSendCmd())
+	cmd.AddCommand(newSessionsGCCmd())
 
 	return cmd
 }
@@ -31,11 +39,16 @@ func newSessionsAttachCmd() *cobra.Command {
 	var projectFlag string
 	var actionFlag string
 	var folderFlag string
+	var newFlag bool
+	var layoutFlag string
+	var paramFlags []string
 
 	cmd := &cobra.Command{
 		Use:   "attach",
 		Short: "Attach to a session",
-		Long:  "Attach to a session using --project or --folder. If using --project, you can optionally specify an --action.",
+		Long: `Attach to a session using --project or --folder. You can optionally specify an --action, which for a
+folder comes from the location with that path, if any. With --project, you can instead open one of the
+project's --layout entries in new terminal tabs and splits.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, err := config.LoadConfig()
 			if err != nil {
@@ -43,14 +56,36 @@ func newSessionsAttachCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
-			target, err := resolveTarget(cmd.Context(), cfg, projectFlag, folderFlag, actionFlag)
+			if layoutFlag != "" {
+				if err := openLayout(cmd.Context(), cfg, projectFlag, layoutFlag); err != nil {
+					fmt.Fprintf(os.Stderr, "error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			sessionManager, err := sessions.NewManager(cfg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error: %v\n", err)
 				os.Exit(1)
 			}
 
-			sessionManager := sessions.NewManager()
-			if err := sessionManager.Attach(target.Name, target.Path, target.Command...); err != nil {
+			target, err := resolveTarget(cmd.Context(), cfg, sessionManager, projectFlag, folderFlag, actionFlag, paramFlags)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if newFlag {
+				instance, err := sessionManager.NewInstance(*target)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error: %v\n", err)
+					os.Exit(1)
+				}
+				target = &instance
+			}
+
+			if err := sessionManager.Attach(*target); err != nil {
 				fmt.Fprintf(os.Stderr, "error attaching to session: %v\n", err)
 				os.Exit(1)
 			}
@@ -60,19 +95,114 @@ func newSessionsAttachCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Project name to attach to")
 	cmd.Flags().StringVarP(&actionFlag, "action", "a", "", "Action name to run (optional, used with --project)")
 	cmd.Flags().StringVarP(&folderFlag, "folder", "f", "", "Folder path to attach to")
+	cmd.Flags().StringVarP(&layoutFlag, "layout", "l", "", "Layout to open in new terminal tabs (used with --project)")
+	cmd.Flags().BoolVar(&newFlag, "new", false, "Start a new numbered instance (e.g. my-app:shell#2) instead of attaching")
+	cmd.Flags().StringArrayVar(&paramFlags, "param", nil, "Action parameter as key=value (repeatable)")
+
+	return cmd
+}
+
+func newSessionsStartCmd() *cobra.Command {
+	var projectFlag string
+	var actionFlag string
+	var folderFlag string
+	var newFlag bool
+	var paramFlags []string
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start a session in the background",
+		Long: `Start a session for --project or --folder without attaching to it, and print its name.
+If the session is already running, its name is printed and nothing is started.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+				os.Exit(1)
+			}
+
+			sessionManager, err := sessions.NewManager(cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			target, err := resolveTarget(cmd.Context(), cfg, sessionManager, projectFlag, folderFlag, actionFlag, paramFlags)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if newFlag {
+				instance, err := sessionManager.NewInstance(*target)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error: %v\n", err)
+					os.Exit(1)
+				}
+				target = &instance
+			}
+
+			if err := sessionManager.Start(*target); err != nil {
+				if !errors.Is(err, sessions.ErrRunning) {
+					fmt.Fprintf(os.Stderr, "error starting session: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Fprintf(os.Stderr, "Session '%s' is already running.\n", target.Name)
+			}
+			fmt.Println(target.Name)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Project name to start")
+	cmd.Flags().StringVarP(&actionFlag, "action", "a", "", "Action name to run (optional, used with --project)")
+	cmd.Flags().StringVarP(&folderFlag, "folder", "f", "", "Folder path to start a session in")
+	cmd.Flags().BoolVar(&newFlag, "new", false, "Start a new numbered instance even if the session is running")
+	cmd.Flags().StringArrayVar(&paramFlags, "param", nil, "Action parameter as key=value (repeatable)")
 
 	return cmd
 }
 
-func resolveTarget(ctx context.Context, cfg *config.Config, projectName, folderPath, actionName string) (*sessions.Target, error) {
+// openLayout opens a project's layout through the detected terminal driver.
+func openLayout(ctx context.Context, cfg *config.Config, projectName, layoutName string) error {
+	if projectName == "" {
+		return fmt.Errorf("--layout requires --project")
+	}
+
+	loc, err := findProject(ctx, cfg, projectName, nil)
+	if err != nil {
+		return err
+	}
+	layout, err := terminal.FindLayout(*loc, layoutName)
+	if err != nil {
+		return err
+	}
+	// Each pane attaches on its own, so settle trust once before opening them
+	var panes []string
+	for _, tab := range layout.Tabs {
+		panes = append(panes, tab.Panes...)
+	}
+	if err := ensureTrusted(*loc, panes...); err != nil {
+		return err
+	}
+
+	driver, n, err := terminal.OpenLocation(cfg.Terminal, *loc, *layout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Opened layout '%s' with %d pane(s) in %s.\n", layout.Name, n, driver.Name())
+	return nil
+}
+
+func resolveTarget(ctx context.Context, cfg *config.Config, sessionManager *sessions.Manager, projectName, folderPath, actionName string, paramPairs []string) (*sessions.Target, error) {
+	params, err := sessions.ParseParams(paramPairs)
+	if err != nil {
+		return nil, err
+	}
+
 	var loc *locations.Location
 
 	if projectName != "" {
-		locMgr, err := setupLocationManager(cfg, true, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to setup location manager: %w", err)
-		}
-		l, err := locMgr.Find(ctx, projectName)
+		l, err := findProject(ctx, cfg, projectName, sessionManager)
 		if err != nil {
 			return nil, err
 		}
@@ -87,51 +217,300 @@ func resolveTarget(ctx context.Context, cfg *config.Config, projectName, folderP
 			return nil, fmt.Errorf("failed to get absolute path: %w", err)
 		}
 
-		loc = &locations.Location{
-			Name:   filepath.Base(absPath),
-			Path:   absPath,
-			Source: "Folder",
-		}
+		loc = findFolder(ctx, cfg, absPath, sessionManager)
 	} else {
 		return nil, fmt.Errorf("must provide --project or --folder")
 	}
 
+	if err := ensureTrusted(*loc, actionName); err != nil {
+		return nil, err
+	}
+
 	shell := env.DetectShell()
-	return sessions.NewManager().Resolve(*loc, actionName, shell, cfg.GetEditor())
+	return sessionManager.ResolveParams(*loc, actionName, shell, cfg.GetEditor(), params)
 }
 
 func newSessionsKillCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "kill [name]",
-		Short: "Kill a session",
-		Args:  cobra.ExactArgs(1),
+	var sel sessions.Selector
+	var projectFlag string
+	var dryRun bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "kill [name|pattern]...",
+		Short: "Kill one or more sessions",
+		Long: `Kill sessions by exact name or glob pattern (e.g. 'my-app:*'), or select them with
+--all, --project or --stale. A confirmation listing the sessions is shown unless --yes
+is given or a single session is named exactly.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			name := args[0]
-			manager := sessions.NewManager()
-			if err := manager.Kill(name); err != nil {
-				fmt.Fprintf(os.Stderr, "error killing session: %v\n", err)
+			sel.Patterns = args
+			if err := sel.Validate(); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+				os.Exit(1)
+			}
+
+			manager, err := sessions.NewManager(cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			if projectFlag != "" {
+				loc, err := findProject(cmd.Context(), cfg, projectFlag, manager)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "error: %v\n", err)
+					os.Exit(1)
+				}
+				sel.Project = loc.Name
+				sel.Names = manager.TargetNames(*loc)
+			}
+
+			if sel.Empty() {
+				fmt.Fprintln(os.Stderr, "error: provide a session name or pattern, or use --all, --project or --stale")
+				os.Exit(1)
+			}
+
+			sessList, err := manager.List()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error listing sessions: %v\n", err)
+				os.Exit(1)
+			}
+
+			targets := sel.Select(sessList)
+			if len(targets) == 0 {
+				// Preserve the single-name behavior for sessions the backend does not list
+				if len(args) == 1 && isLiteralName(args[0]) && sel.Project == "" && !sel.All && !sel.Stale {
+					targets = []sessions.Session{{ID: args[0]}}
+				} else {
+					fmt.Println("No matching sessions found.")
+					return
+				}
+			}
+
+			if dryRun {
+				fmt.Println("Would kill:")
+				for _, s := range targets {
+					fmt.Printf("  %s\n", s.ID)
+				}
+				return
+			}
+
+			singleExact := len(targets) == 1 && len(args) == 1 && targets[0].ID == args[0]
+			if !yes && !singleExact {
+				fmt.Println("The following sessions will be killed:")
+				for _, s := range targets {
+					fmt.Printf("  %s\n", s.ID)
+				}
+				if !confirm(fmt.Sprintf("Kill %d session(s)?", len(targets))) {
+					fmt.Println("Aborted.")
+					return
+				}
+			}
+
+			failed := false
+			for _, s := range targets {
+				if err := manager.Kill(s.ID); err != nil {
+					fmt.Fprintf(os.Stderr, "error killing session: %v\n", err)
+					failed = true
+					continue
+				}
+				fmt.Printf("Session '%s' killed.\n", s.ID)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&sel.All, "all", false, "Kill all sessions")
+	cmd.Flags().StringVarP(&projectFlag, "project", "p", "", "Kill every session belonging to a project")
+	cmd.Flags().BoolVar(&sel.Stale, "stale", false, "Kill sessions whose working directory no longer exists")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be killed without killing anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func newSessionsSendCmd() *cobra.Command {
+	var noEnter bool
+
+	cmd := &cobra.Command{
+		Use:   "send <name> <text>...",
+		Short: "Type a command into a running session",
+		Long: `Type text into a running session without attaching, e.g. to rerun tests:
+
+  atelier-go sessions send my-app:tests "npm test"
+
+The text is submitted with Enter unless --no-enter is given. Supported by the
+tmux and zmx backends (zmx always submits).`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			manager := newSessionManager()
+			text := strings.Join(args[1:], " ")
+			if err := manager.Send(args[0], text, !noEnter); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("Session '%s' killed.\n", name)
 		},
 	}
+
+	cmd.Flags().BoolVar(&noEnter, "no-enter", false, "Type the text without pressing Enter")
+
+	return cmd
+}
+
+func newSessionsGCCmd() *cobra.Command {
+	var maxIdleFlag string
+	var keepFlag []string
+	var dryRun bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Kill sessions that have not been attached to recently",
+		Long: `Kill sessions whose last attach is older than the idle threshold, set with
+gc.max-idle in the config or --max-idle. Sessions matching gc.keep or --keep (name
+globs or project names) are never killed, nor are sessions atelier-go has no record of.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+				os.Exit(1)
+			}
+
+			gc := cfg.GC
+			if maxIdleFlag != "" {
+				gc.MaxIdle = maxIdleFlag
+			}
+			maxIdle, err := gc.GetMaxIdle()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			if maxIdle <= 0 {
+				fmt.Fprintln(os.Stderr, "error: no idle threshold; set gc.max-idle in the config or pass --max-idle")
+				os.Exit(1)
+			}
+			policy := sessions.GCPolicy{MaxIdle: maxIdle, Keep: append(gc.Keep, keepFlag...)}
+
+			manager, err := sessions.NewManager(cfg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			idle, err := manager.IdleSessions(policy)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error listing sessions: %v\n", err)
+				os.Exit(1)
+			}
+			if len(idle) == 0 {
+				fmt.Println("No idle sessions found.")
+				return
+			}
+
+			header := "The following idle sessions will be killed:"
+			if dryRun {
+				header = "Would kill:"
+			}
+			fmt.Println(header)
+			for _, s := range idle {
+				fmt.Printf("  %s (idle %s)\n", s.ID, formatIdle(time.Since(s.LastActive)))
+			}
+			if dryRun {
+				return
+			}
+			if !yes && !confirm(fmt.Sprintf("Kill %d session(s)?", len(idle))) {
+				fmt.Println("Aborted.")
+				return
+			}
+
+			failed := false
+			for _, s := range idle {
+				if err := manager.Kill(s.ID); err != nil {
+					fmt.Fprintf(os.Stderr, "error killing session: %v\n", err)
+					failed = true
+					continue
+				}
+				fmt.Printf("Session '%s' killed.\n", s.ID)
+			}
+			if failed {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&maxIdleFlag, "max-idle", "", "Idle threshold, e.g. 72h (overrides gc.max-idle)")
+	cmd.Flags().StringSliceVar(&keepFlag, "keep", nil, "Additional session globs or project names to protect")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be killed without killing anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+// formatIdle renders an idle duration in days and hours, e.g. "3d4h".
+func formatIdle(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours < 24 {
+		return d.Round(time.Minute).String()
+	}
+	return fmt.Sprintf("%dd%dh", hours/24, hours%24)
+}
+
+// isLiteralName reports whether a kill argument contains no glob characters.
+func isLiteralName(s string) bool {
+	return !strings.ContainsAny(s, "*?[\\")
 }
 
 func newSessionsListCmd() *cobra.Command {
-	return &cobra.Command{
+	var outputOpts output.Options
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List active sessions",
 		Run: func(cmd *cobra.Command, args []string) {
-			manager := sessions.NewManager()
+			if err := outputOpts.Validate(); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+
+			manager := newSessionManager()
 			sessList, err := manager.List()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error listing sessions: %v\n", err)
 				os.Exit(1)
 			}
 
-			if err := manager.PrintTable(os.Stdout, sessList); err != nil {
+			if err := output.Write(os.Stdout, outputOpts, manager.Renderer(), sessList); err != nil {
 				fmt.Fprintf(os.Stderr, "error printing sessions: %v\n", err)
 			}
 		},
 	}
+
+	addOutputFlags(cmd, &outputOpts)
+
+	return cmd
+}
+
+// newSessionManager loads the configuration and builds a session manager,
+// exiting on failure.
+func newSessionManager() *sessions.Manager {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	manager, err := sessions.NewManager(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return manager
 }
@@ -2,24 +2,167 @@ package cli
 
 import (
 	"atelier-go/internal/config"
+	"atelier-go/internal/discover"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
 )
 
-// setupLocationManager creates a locations.Manager based on the provided flags.
-func setupLocationManager(cfg *config.Config, includeProjects, includeZoxide bool) (*locations.Manager, error) {
-	// Default to showing both if neither is specified
-	if !includeProjects && !includeZoxide {
-		includeProjects = true
-		includeZoxide = true
+// setupLocationManager creates a locations.Manager from the configured
+// providers, keeping only those named in sources when any are given.
+func setupLocationManager(cfg *config.Config, sources []string) (*locations.Manager, error) {
+	selected, err := selectProviders(cfg.GetProviders(), sources)
+	if err != nil {
+		return nil, err
+	}
+	return newLocationManager(cfg, selected)
+}
+
+// setupProjectManager creates a locations.Manager for looking up projects by
+// name, from the enabled projects and git providers.
+func setupProjectManager(cfg *config.Config) (*locations.Manager, error) {
+	var selected []config.Provider
+	for _, p := range cfg.GetProviders() {
+		if p.IsEnabled() && (p.Name == config.ProviderProjects || p.Name == config.ProviderGit) {
+			selected = append(selected, p)
+		}
+	}
+	return newLocationManager(cfg, selected)
+}
+
+// findProject looks up a project by name, warning on stderr about providers
+// that failed, since the match may not be the project that was asked for.
+// When sessionManager is given, it is told about all the projects so one
+// sharing its name with another gets a distinct session name.
+func findProject(ctx context.Context, cfg *config.Config, name string, sessionManager *sessions.Manager) (*locations.Location, error) {
+	locMgr, err := setupProjectManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup location manager: %w", err)
+	}
+	locs, failed := locMgr.GetAll(ctx)
+	for _, f := range failed {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", f)
+	}
+	loc, err := locations.Lookup(locs, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) > 0 && !strings.EqualFold(loc.Name, name) {
+		fmt.Fprintf(os.Stderr, "warning: using closest match %q for %q\n", loc.Name, name)
+	}
+	if sessionManager != nil {
+		sessionManager.SetLocations(locs)
+	}
+	return loc, nil
+}
+
+// findFolder returns the known location with the given path, from any enabled
+// provider, so its actions and settings apply. Other directories are returned
+// as plain folders.
+func findFolder(ctx context.Context, cfg *config.Config, path string, sessionManager *sessions.Manager) *locations.Location {
+	folder := &locations.Location{Name: filepath.Base(path), Path: path, Source: "Folder"}
+	locMgr, err := setupLocationManager(cfg, nil)
+	if err != nil {
+		return folder
 	}
+	locs, failed := locMgr.GetAll(ctx)
+	loc := locations.LookupPath(locs, path)
+	if loc == nil {
+		for _, f := range failed {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", f)
+		}
+		return folder
+	}
+	if sessionManager != nil {
+		sessionManager.SetLocations(locs)
+	}
+	return loc
+}
+
+// newLocationManager builds the given providers in order.
+func newLocationManager(cfg *config.Config, selected []config.Provider) (*locations.Manager, error) {
+	// Providers share one discoverer so its cache is written consistently
+	discoverer := discover.NewDefault()
+	var globalDiscoverer *discover.Discoverer
+	if cfg.GetDiscoverActions() {
+		globalDiscoverer = discoverer
+	}
+
+	// Earlier providers win when several return the same path, except that
+	// explicit projects keep their settings over scanned repositories
+	manager := locations.NewManager()
+	for _, settings := range selected {
+		var p locations.Provider
+		switch settings.Name {
+		case config.ProviderProjects:
+			p = locations.NewProjectProvider(cfg.Projects, cfg.Actions, cfg.GetShellDefault(), discoverer, cfg.GetDiscoverActions())
+		case config.ProviderGit:
+			if len(cfg.Git.Roots) == 0 {
+				continue
+			}
+			p = locations.NewGitProvider(cfg.Git, cfg.Actions, cfg.GetShellDefault(), globalDiscoverer)
+		case config.ProviderZoxide:
+			zoxide, err := locations.NewZoxideProvider(settings, cfg.Actions, cfg.GetShellDefault(), cfg.Rules, globalDiscoverer)
+			if err != nil {
+				return nil, err
+			}
+			p = zoxide
+		}
+
+		if cfg.GetWorktrees() {
+			p = locations.NewWorktreeProvider(p)
+		}
+		if settings.Icon != "" || settings.Label != "" {
+			p = locations.NewDisplayProvider(p, settings.Icon, settings.Label)
+		}
+		// Validated with the config, so a parse error cannot happen here
+		timeout, _ := settings.GetTimeout()
+		manager.AddProvider(p, timeout)
+	}
+
+	return manager, nil
+}
 
-	var providers []locations.Provider
-	if includeProjects {
-		providers = append(providers, locations.NewProjectProvider(cfg.Projects, cfg.Actions, cfg.GetShellDefault()))
+// selectProviders returns the enabled providers, in order, limited to the
+// given sources. A source matches a provider's name or label.
+func selectProviders(all []config.Provider, sources []string) ([]config.Provider, error) {
+	var selected, enabled []config.Provider
+	for _, p := range all {
+		if p.IsEnabled() {
+			enabled = append(enabled, p)
+		}
 	}
-	if includeZoxide {
-		providers = append(providers, locations.NewZoxideProvider(cfg.Actions, cfg.GetShellDefault()))
+	if len(sources) == 0 {
+		return enabled, nil
 	}
 
-	return locations.NewManager(providers...), nil
+	matched := make(map[string]bool)
+	names := make([]string, len(enabled))
+	for i, p := range enabled {
+		names[i] = p.Name
+		for _, source := range sources {
+			if strings.EqualFold(source, p.Name) || (p.Label != "" && strings.EqualFold(source, p.Label)) {
+				matched[strings.ToLower(source)] = true
+				selected = append(selected, p)
+				break
+			}
+		}
+	}
+	for _, source := range sources {
+		if !matched[strings.ToLower(source)] {
+			return nil, fmt.Errorf("unknown source %q (enabled: %s)", source, strings.Join(names, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// addSourceFlag registers the --source filter on a command.
+func addSourceFlag(cmd *cobra.Command, sources *[]string) {
+	cmd.Flags().StringSliceVarP(sources, "source", "s", nil, "Only show locations from these providers (e.g. projects, git, zoxide, or a label)")
 }
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/trust"
+	"atelier-go/internal/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newTrustCmd() *cobra.Command {
+	var revoke bool
+
+	cmd := &cobra.Command{
+		Use:   "trust [dir]",
+		Short: "Allow the commands in a directory's .atelier.yaml to run",
+		Long: `Record the current content of a directory's .atelier.yaml as trusted.
+Actions from a project file only run once it is trusted, and the file must be
+trusted again whenever it changes. Defaults to the current directory.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			if err := runTrust(dir, revoke); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&revoke, "revoke", false, "Forget the file instead of trusting it")
+
+	return cmd
+}
+
+func runTrust(dir string, revoke bool) error {
+	dir, err := utils.ExpandPath(dir)
+	if err != nil {
+		return fmt.Errorf("failed to expand path: %w", err)
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	store, err := trust.DefaultStore()
+	if err != nil {
+		return err
+	}
+	if revoke {
+		if err := store.Revoke(filepath.Join(dir, config.ProjectFileName)); err != nil {
+			return err
+		}
+		fmt.Printf("No longer trusting %s\n", filepath.Join(dir, config.ProjectFileName))
+		return nil
+	}
+
+	pf, err := config.LoadProjectFile(dir)
+	if err != nil {
+		return err
+	}
+	if pf == nil {
+		return fmt.Errorf("no %s in %s", config.ProjectFileName, dir)
+	}
+	if err := store.Trust(pf.Path, pf.Hash); err != nil {
+		return err
+	}
+	fmt.Printf("Trusted %s\n", pf.Path)
+	return nil
+}
+
+// ensureTrusted makes sure the actions that would run for a location may
+// run. Actions from a project file that is new or has changed since it was
+// last trusted need a yes on stdin, which then trusts the file; without one
+// it fails with a hint.
+func ensureTrusted(loc locations.Location, actionNames ...string) error {
+	var store *trust.Store
+	asked := make(map[string]bool)
+	for _, name := range actionNames {
+		act := findAction(loc, name)
+		if act == nil || act.Origin == "" || asked[act.Origin] {
+			continue
+		}
+		if store == nil {
+			var err error
+			if store, err = trust.DefaultStore(); err != nil {
+				return err
+			}
+		}
+		ok, err := store.IsTrusted(act.Origin, act.OriginHash)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		asked[act.Origin] = true
+		if !confirm(fmt.Sprintf("Run commands from %s? The file is new or has changed.", act.Origin)) {
+			return fmt.Errorf("%s is not trusted; review it and run 'atelier-go trust %s'", act.Origin, filepath.Dir(act.Origin))
+		}
+		if err := store.Trust(act.Origin, act.OriginHash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findAction returns the configured action that resolving actionName would
+// use, or nil for the built-in shell and editor.
+func findAction(loc locations.Location, actionName string) *config.Action {
+	if actionName == "" {
+		if len(loc.Actions) == 0 {
+			return nil
+		}
+		return &loc.Actions[0]
+	}
+	for i, act := range loc.Actions {
+		if utils.Sanitize(act.Name) == utils.Sanitize(actionName) {
+			return &loc.Actions[i]
+		}
+	}
+	return nil
+}
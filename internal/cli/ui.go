@@ -10,8 +10,7 @@ import (
 )
 
 func newUICmd() *cobra.Command {
-	var showProjects bool
-	var showZoxide bool
+	var sources []string
 
 	cmd := &cobra.Command{
 		Use:     "ui",
@@ -26,7 +25,7 @@ func newUICmd() *cobra.Command {
 
 			clientID, _ := cmd.Flags().GetString("client-id")
 
-			mgr, err := setupLocationManager(cfg, showProjects, showZoxide)
+			mgr, err := setupLocationManager(cfg, sources)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "error: %v\n", err)
 				os.Exit(1)
@@ -38,8 +37,7 @@ func newUICmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().BoolVarP(&showProjects, "projects", "p", false, "Show projects only")
-	cmd.Flags().BoolVarP(&showZoxide, "zoxide", "z", false, "Show zoxide directories only")
+	addSourceFlag(cmd, &sources)
 
 	return cmd
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -64,7 +66,14 @@ func LoadConfig() (*Config, error) {
 func (c *Config) Merge(other Config) {
 	c.Projects = mergeProjects(c.Projects, other.Projects)
 	c.Actions = MergeActions(c.Actions, other.Actions)
+	c.Rules = append(c.Rules, other.Rules...)
+	if len(other.Providers) > 0 {
+		// The list is ordered, so a local one replaces it as a whole
+		c.Providers = other.Providers
+	}
 	c.Theme = mergeTheme(c.Theme, other.Theme)
+	c.Env = MergeEnv(c.Env, other.Env)
+	c.EnvFiles = append(c.EnvFiles, other.EnvFiles...)
 
 	if other.Editor != "" {
 		c.Editor = other.Editor
@@ -72,6 +81,36 @@ func (c *Config) Merge(other Config) {
 	if other.ShellDefault != nil {
 		c.ShellDefault = other.ShellDefault
 	}
+	if other.DiscoverActions != nil {
+		c.DiscoverActions = other.DiscoverActions
+	}
+	if other.Worktrees != nil {
+		c.Worktrees = other.Worktrees
+	}
+	if other.SessionBackend != "" {
+		c.SessionBackend = other.SessionBackend
+	}
+	if other.SessionName != "" {
+		c.SessionName = other.SessionName
+	}
+	if other.Terminal.Driver != "" {
+		c.Terminal.Driver = other.Terminal.Driver
+	}
+	if other.Terminal.Command != "" {
+		c.Terminal.Command = other.Terminal.Command
+	}
+	c.Git.Roots = append(c.Git.Roots, other.Git.Roots...)
+	c.Git.Ignore = append(c.Git.Ignore, other.Git.Ignore...)
+	if other.Git.MaxDepth != 0 {
+		c.Git.MaxDepth = other.Git.MaxDepth
+	}
+	if other.Git.Parallel != 0 {
+		c.Git.Parallel = other.Git.Parallel
+	}
+	if other.GC.MaxIdle != "" {
+		c.GC.MaxIdle = other.GC.MaxIdle
+	}
+	c.GC.Keep = append(c.GC.Keep, other.GC.Keep...)
 }
 
 // mergeTheme merges two themes. Local values override global.
@@ -119,6 +158,10 @@ func mergeProjects(global, local []Project) []Project {
 
 // MergeActions merges two action slices. Global actions are preserved in order,
 // but overridden by specific actions if names match (case-insensitive).
+// An overriding action keeps the global action's environment, with its own
+// variables taking precedence and its env files loaded after the global ones.
+// Inherited environment from a project file keeps that file as the action's
+// origin, so it still has to be trusted.
 // Strictly new specific actions are appended to the end.
 func MergeActions(global, specific []Action) []Action {
 	specificMap := make(map[string]Action)
@@ -133,6 +176,13 @@ func MergeActions(global, specific []Action) []Action {
 	for _, a := range global {
 		key := utils.Sanitize(a.Name)
 		if sa, ok := specificMap[key]; ok {
+			sa.Env = MergeEnv(a.Env, sa.Env)
+			if len(a.EnvFiles) > 0 {
+				sa.EnvFiles = append(append([]string{}, a.EnvFiles...), sa.EnvFiles...)
+			}
+			if a.Origin != "" && sa.Origin == "" && (len(a.Env) > 0 || len(a.EnvFiles) > 0) {
+				sa.Origin, sa.OriginHash = a.Origin, a.OriginHash
+			}
 			merged = append(merged, sa)
 			processed[key] = true
 		} else {
@@ -151,6 +201,22 @@ func MergeActions(global, specific []Action) []Action {
 	return merged
 }
 
+// MergeEnv merges two sets of environment variables. Values in override win.
+// It returns nil when both are empty.
+func MergeEnv(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
 // UseDefaultActions returns true if the project should use default actions.
 func (p Project) UseDefaultActions() bool {
 	if p.DefaultActions == nil {
@@ -159,6 +225,33 @@ func (p Project) UseDefaultActions() bool {
 	return *p.DefaultActions
 }
 
+// IsEnabled reports whether the provider is turned on, which it is unless
+// enabled is set to false.
+func (p Provider) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
+}
+
+// GetProviders returns the configured providers in order, defaulting to
+// projects, then git, then zoxide.
+func (c *Config) GetProviders() []Provider {
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+	return []Provider{{Name: ProviderProjects}, {Name: ProviderGit}, {Name: ProviderZoxide}}
+}
+
+// GetTimeout parses the provider's timeout. Zero means the default applies.
+func (p Provider) GetTimeout() (time.Duration, error) {
+	if p.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(p.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", p.Timeout, err)
+	}
+	return d, nil
+}
+
 // GetShellDefault returns the shell-default setting for the project.
 // If not set, it inherits from the root setting.
 func (p Project) GetShellDefault(rootDefault bool) bool {
@@ -168,6 +261,32 @@ func (p Project) GetShellDefault(rootDefault bool) bool {
 	return *p.ShellDefault
 }
 
+// GetDiscoverActions returns the discover-actions setting for the project.
+// If not set, it inherits from the root setting.
+func (p Project) GetDiscoverActions(rootDefault bool) bool {
+	if p.DiscoverActions == nil {
+		return rootDefault
+	}
+	return *p.DiscoverActions
+}
+
+// GetDiscoverActions returns the root discover-actions setting, off by default.
+func (c *Config) GetDiscoverActions() bool {
+	if c.DiscoverActions == nil {
+		return false
+	}
+	return *c.DiscoverActions
+}
+
+// GetWorktrees returns whether git worktrees are listed under their
+// repository, on by default.
+func (c *Config) GetWorktrees() bool {
+	if c.Worktrees == nil {
+		return true
+	}
+	return *c.Worktrees
+}
+
 // GetShellDefault returns the root shell-default setting.
 func (c *Config) GetShellDefault() bool {
 	if c.ShellDefault == nil {
@@ -176,6 +295,44 @@ func (c *Config) GetShellDefault() bool {
 	return *c.ShellDefault
 }
 
+// GetSessionBackend returns the configured session backend, defaulting to
+// DefaultSessionBackend.
+func (c *Config) GetSessionBackend() string {
+	if c.SessionBackend != "" {
+		return c.SessionBackend
+	}
+	return DefaultSessionBackend
+}
+
+// GetMaxDepth returns the git scan depth, defaulting to 3.
+func (g Git) GetMaxDepth() int {
+	if g.MaxDepth > 0 {
+		return g.MaxDepth
+	}
+	return 3
+}
+
+// GetParallel returns the number of directories the git scan reads at once,
+// defaulting to the CPU count.
+func (g Git) GetParallel() int {
+	if g.Parallel > 0 {
+		return g.Parallel
+	}
+	return runtime.NumCPU()
+}
+
+// GetMaxIdle parses the gc max-idle setting. Zero means no threshold is configured.
+func (g GC) GetMaxIdle() (time.Duration, error) {
+	if g.MaxIdle == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(g.MaxIdle)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gc max-idle %q: %w", g.MaxIdle, err)
+	}
+	return d, nil
+}
+
 // GetEditor returns the configured editor or fallbacks.
 func (c *Config) GetEditor() string {
 	if c.Editor != "" {
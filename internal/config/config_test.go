@@ -76,6 +76,9 @@ func TestLoadConfig_NoFile(t *testing.T) {
 	if cfg.Editor != "vim" {
 		t.Errorf("expected default editor vim, got %s", cfg.Editor)
 	}
+	if cfg.SessionBackend != "zmx" {
+		t.Errorf("expected default session-backend zmx, got %s", cfg.SessionBackend)
+	}
 	// Verify corrected theme defaults
 	if cfg.Theme.Primary != "#89b4fa" {
 		t.Errorf("expected default theme.primary #89b4fa, got %s", cfg.Theme.Primary)
@@ -166,27 +169,34 @@ func TestConfig_Merge(t *testing.T) {
 		},
 		Actions: []Action{
 			{Name: "a0", Command: "c0"},
-			{Name: "a1", Command: "c1"},
+			{Name: "a1", Command: "c1", Env: map[string]string{"A": "global", "B": "global"}, EnvFiles: []string{".env"}},
 		},
+		Env:      map[string]string{"EDITOR": "vim", "LANG": "C"},
+		EnvFiles: []string{"~/.env"},
 		Theme: Theme{
 			Primary: "red",
 			Accent:  "blue",
 		},
+		GC: GC{MaxIdle: "72h", Keep: []string{"dotfiles:*"}},
 	}
 
 	host := Config{
-		Editor: "nano", // Override
+		Editor:         "nano", // Override
+		SessionBackend: "tmux", // Override
 		Projects: []Project{
 			{Name: "p1", Path: "/p1-host"}, // Override
 			{Name: "p3", Path: "/p3"},      // Append
 		},
 		Actions: []Action{
-			{Name: "a1", Command: "c1-host"}, // Override
-			{Name: "a2", Command: "c2"},      // Append
+			{Name: "a1", Command: "c1-host", Env: map[string]string{"B": "host"}, EnvFiles: []string{".env.local"}}, // Override
+			{Name: "a2", Command: "c2"}, // Append
 		},
 		Theme: Theme{
 			Primary: "green", // Override
 		},
+		GC:       GC{Keep: []string{"notes:*"}},      // Append
+		Env:      map[string]string{"LANG": "en_US"}, // Merge by key
+		EnvFiles: []string{"~/.env.host"},            // Append
 	}
 
 	global.Merge(host)
@@ -196,6 +206,14 @@ func TestConfig_Merge(t *testing.T) {
 		t.Errorf("expected editor nano, got %s", global.Editor)
 	}
 
+	if global.SessionBackend != "tmux" {
+		t.Errorf("expected session backend tmux, got %s", global.SessionBackend)
+	}
+
+	if global.GC.MaxIdle != "72h" || len(global.GC.Keep) != 2 {
+		t.Errorf("expected gc max-idle 72h with 2 keep entries, got %+v", global.GC)
+	}
+
 	// Check Projects
 	if len(global.Projects) != 3 {
 		t.Errorf("expected 3 projects, got %d", len(global.Projects))
@@ -221,6 +239,23 @@ func TestConfig_Merge(t *testing.T) {
 		t.Errorf("expected a2 appended at index 2, got %v", global.Actions[2])
 	}
 
+	// Overriding actions keep the global environment underneath their own
+	a1 := global.Actions[1]
+	if a1.Env["A"] != "global" || a1.Env["B"] != "host" {
+		t.Errorf("expected merged action env, got %v", a1.Env)
+	}
+	if len(a1.EnvFiles) != 2 || a1.EnvFiles[0] != ".env" || a1.EnvFiles[1] != ".env.local" {
+		t.Errorf("expected global env files first, got %v", a1.EnvFiles)
+	}
+
+	// Check Env
+	if global.Env["EDITOR"] != "vim" || global.Env["LANG"] != "en_US" {
+		t.Errorf("expected env merged by key, got %v", global.Env)
+	}
+	if len(global.EnvFiles) != 2 || global.EnvFiles[1] != "~/.env.host" {
+		t.Errorf("expected env files appended, got %v", global.EnvFiles)
+	}
+
 	// Check Theme
 	if global.Theme.Primary != "green" {
 		t.Errorf("expected theme primary green, got %s", global.Theme.Primary)
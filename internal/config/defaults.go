@@ -8,6 +8,7 @@ import (
 func SetDefaults(v *viper.Viper) {
 	v.SetDefault("editor", "vim")
 	v.SetDefault("shell-default", false)
+	v.SetDefault("session-backend", "zmx")
 
 	// Theme defaults
 	v.SetDefault("theme.primary", "#89b4fa")
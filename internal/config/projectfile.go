@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// ProjectFileName is the name of the project-local config file.
+const ProjectFileName = ".atelier.yaml"
+
+// ProjectFile is a project-local config file shipped in a repository.
+type ProjectFile struct {
+	Actions      []Action `mapstructure:"actions"`
+	ShellDefault *bool    `mapstructure:"shell-default"`
+
+	// Path is the file's location and Hash the SHA-256 of its content.
+	Path string `mapstructure:"-"`
+	Hash string `mapstructure:"-"`
+}
+
+// LoadProjectFile reads the .atelier.yaml in dir. It returns nil without an
+// error when there is no such file. Every action is tagged with the file it
+// came from, so it can be checked against the trust store before it runs.
+func LoadProjectFile(dir string) (*ProjectFile, error) {
+	path := filepath.Join(dir, ProjectFileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var pf ProjectFile
+	if err := v.Unmarshal(&pf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	for _, a := range pf.Actions {
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	pf.Path = path
+	pf.Hash = hex.EncodeToString(sum[:])
+	for i := range pf.Actions {
+		pf.Actions[i].Origin = path
+		pf.Actions[i].OriginHash = pf.Hash
+	}
+	return &pf, nil
+}
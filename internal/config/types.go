@@ -2,26 +2,220 @@ package config
 
 // Project represents a defined project with a name and a filesystem path.
 type Project struct {
-	Name           string   `mapstructure:"name"`
-	Path           string   `mapstructure:"path"`
-	Actions        []Action `mapstructure:"actions"`
-	DefaultActions *bool    `mapstructure:"default-actions"`
-	ShellDefault   *bool    `mapstructure:"shell-default"`
+	Name            string            `mapstructure:"name"`
+	Path            string            `mapstructure:"path"`
+	Actions         []Action          `mapstructure:"actions"`
+	DefaultActions  *bool             `mapstructure:"default-actions"`
+	ShellDefault    *bool             `mapstructure:"shell-default"`
+	DiscoverActions *bool             `mapstructure:"discover-actions"`
+	SessionBackend  string            `mapstructure:"session-backend"`
+	Hooks           Hooks             `mapstructure:"hooks"`
+	Layouts         []Layout          `mapstructure:"layouts"`
+	Env             map[string]string `mapstructure:"env"`
+	EnvFiles        []string          `mapstructure:"env-files"`
 }
 
 // Action represents a runnable command associated with a project.
 type Action struct {
-	Name    string `mapstructure:"name"`
+	Name    string `mapstructure:"name" json:"name" yaml:"name"`
+	Command string `mapstructure:"command" json:"command" yaml:"command"`
+	// Args runs a program directly instead of Command, without the interactive
+	// login shell wrapper.
+	Args []string `mapstructure:"args" json:"args,omitempty" yaml:"args,omitempty"`
+	// Cwd is the directory the action runs in, relative to the project path.
+	Cwd   string `mapstructure:"cwd" json:"cwd,omitempty" yaml:"cwd,omitempty"`
+	Hooks Hooks  `mapstructure:"hooks" json:"hooks,omitzero" yaml:"hooks,omitempty"`
+	// Params are values asked for before the action runs, available to its
+	// templates as {{.Params.name}}.
+	Params []Param `mapstructure:"params" json:"params,omitempty" yaml:"params,omitempty"`
+	// Template turns on Go template expansion in command, args and cwd. It
+	// defaults to on for actions with params and off otherwise, so literal
+	// braces such as docker's --format '{{.Names}}' keep working.
+	Template *bool `mapstructure:"template" json:"template,omitempty" yaml:"template,omitempty"`
+	// Env and EnvFiles set environment variables for the action's session.
+	Env      map[string]string `mapstructure:"env" json:"env,omitempty" yaml:"env,omitempty"`
+	EnvFiles []string          `mapstructure:"env-files" json:"env_files,omitempty" yaml:"env_files,omitempty"`
+	// When limits the action to locations matching its conditions.
+	When *When `mapstructure:"when" json:"when,omitempty" yaml:"when,omitempty"`
+	// Discovered is set on actions found in the location's task files rather
+	// than configured.
+	Discovered bool `mapstructure:"-" json:"discovered,omitempty" yaml:"discovered,omitempty"`
+	// Origin is the project file the action was defined in, and OriginHash
+	// the hash of that file when it was read.
+	Origin     string `mapstructure:"-" json:"origin,omitempty" yaml:"origin,omitempty"`
+	OriginHash string `mapstructure:"-" json:"origin_hash,omitempty" yaml:"origin_hash,omitempty"`
+}
+
+// When holds the conditions under which an action is offered.
+// Every condition that is set must match; a condition listing several
+// values matches when any of them does.
+type When struct {
+	// FileExists lists paths, relative to the location, of which one must exist.
+	FileExists []string `mapstructure:"file-exists" json:"file_exists,omitempty" yaml:"file_exists,omitempty"`
+	// Host lists host name globs, e.g. "workstation-*".
+	Host []string `mapstructure:"host" json:"host,omitempty" yaml:"host,omitempty"`
+	// OS lists operating systems as named by Go, e.g. "linux" or "darwin".
+	OS []string `mapstructure:"os" json:"os,omitempty" yaml:"os,omitempty"`
+	// Env lists variables that must be set ("VAR") or have a value ("VAR=value").
+	Env []string `mapstructure:"env" json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// Param is a value supplied when an action is chosen.
+type Param struct {
+	Name    string   `mapstructure:"name" json:"name" yaml:"name"`
+	Prompt  string   `mapstructure:"prompt" json:"prompt,omitempty" yaml:"prompt,omitempty"`
+	Default string   `mapstructure:"default" json:"default,omitempty" yaml:"default,omitempty"`
+	Choices []string `mapstructure:"choices" json:"choices,omitempty" yaml:"choices,omitempty"`
+}
+
+// UsesTemplate reports whether the action's command, args and cwd are
+// expanded as templates.
+func (a Action) UsesTemplate() bool {
+	if a.Template != nil {
+		return *a.Template
+	}
+	return len(a.Params) > 0
+}
+
+// RequiredParams returns the names of the action's params without a default,
+// which must be given a value whenever the action runs.
+func (a Action) RequiredParams() []string {
+	var names []string
+	for _, p := range a.Params {
+		if p.Default == "" {
+			names = append(names, p.Name)
+		}
+	}
+	return names
+}
+
+// Label returns the prompt shown when asking for the parameter.
+func (p Param) Label() string {
+	if p.Prompt != "" {
+		return p.Prompt
+	}
+	return p.Name
+}
+
+// Hooks holds shell commands run at points in a session's lifecycle.
+type Hooks struct {
+	OnCreate   string `mapstructure:"on-create" json:"on_create,omitempty" yaml:"on_create,omitempty"`
+	PreAttach  string `mapstructure:"pre-attach" json:"pre_attach,omitempty" yaml:"pre_attach,omitempty"`
+	PostDetach string `mapstructure:"post-detach" json:"post_detach,omitempty" yaml:"post_detach,omitempty"`
+	OnExit     string `mapstructure:"on-exit" json:"on_exit,omitempty" yaml:"on_exit,omitempty"`
+}
+
+// Layout arranges several actions in native terminal tabs and splits.
+type Layout struct {
+	Name string      `mapstructure:"name" json:"name" yaml:"name"`
+	Tabs []LayoutTab `mapstructure:"tabs" json:"tabs" yaml:"tabs"`
+}
+
+// LayoutTab is a terminal tab whose panes each attach to an action's session.
+type LayoutTab struct {
+	Title string `mapstructure:"title" json:"title,omitempty" yaml:"title,omitempty"`
+	// Split is the direction additional panes open in: "right" (default) or "down".
+	Split string   `mapstructure:"split" json:"split,omitempty" yaml:"split,omitempty"`
+	Panes []string `mapstructure:"panes" json:"panes" yaml:"panes"`
+}
+
+// Terminal configures how layouts open tabs and splits.
+type Terminal struct {
+	// Driver is "auto" (default), "kitty", "wezterm", "ghostty", "gnome-terminal",
+	// "konsole" or "command".
+	Driver string `mapstructure:"driver"`
+	// Command is the template used by the "command" driver to open a new window.
 	Command string `mapstructure:"command"`
 }
 
 // Config represents the application configuration.
 type Config struct {
-	Projects     []Project `mapstructure:"projects"`
-	Actions      []Action  `mapstructure:"actions"`
-	ShellDefault *bool     `mapstructure:"shell-default"`
-	Editor       string    `mapstructure:"editor"`
-	Theme        Theme     `mapstructure:"theme"`
+	Projects        []Project         `mapstructure:"projects"`
+	Actions         []Action          `mapstructure:"actions"`
+	Rules           []Rule            `mapstructure:"rules"`
+	Providers       []Provider        `mapstructure:"providers"`
+	Git             Git               `mapstructure:"git"`
+	Worktrees       *bool             `mapstructure:"worktrees"`
+	ShellDefault    *bool             `mapstructure:"shell-default"`
+	DiscoverActions *bool             `mapstructure:"discover-actions"`
+	Editor          string            `mapstructure:"editor"`
+	SessionBackend  string            `mapstructure:"session-backend"`
+	SessionName     string            `mapstructure:"session-name"`
+	GC              GC                `mapstructure:"gc"`
+	Terminal        Terminal          `mapstructure:"terminal"`
+	Env             map[string]string `mapstructure:"env"`
+	EnvFiles        []string          `mapstructure:"env-files"`
+	Theme           Theme             `mapstructure:"theme"`
+}
+
+// Rule customizes zoxide directories whose path matches a pattern.
+type Rule struct {
+	// Match is a path glob where "**" matches any number of directories,
+	// e.g. "~/work/**/services/*".
+	Match string `mapstructure:"match"`
+	// Regex is a regular expression matched against the whole path, used
+	// instead of Match.
+	Regex string `mapstructure:"regex"`
+	// Name is a template for the display name, e.g. "{{.Parent}}/{{.Dir}}".
+	Name         string   `mapstructure:"name"`
+	Actions      []Action `mapstructure:"actions"`
+	ShellDefault *bool    `mapstructure:"shell-default"`
+}
+
+// DefaultSessionBackend is the session backend used when none is configured.
+const DefaultSessionBackend = "zmx"
+
+// SessionBackends lists the session backend names accepted in configuration.
+var SessionBackends = []string{DefaultSessionBackend, "tmux", "shpool", "abduco"}
+
+// SessionNameFields lists the values available to the session-name template.
+var SessionNameFields = []string{"Project", "Action", "Source", "Dir", "Host"}
+
+// Provider names.
+const (
+	ProviderProjects = "projects"
+	ProviderGit      = "git"
+	ProviderZoxide   = "zoxide"
+)
+
+// Provider enables a location source. The order of the providers list sets
+// the order of locations and which one wins when several return the same
+// directory, except that a configured project always keeps its settings.
+type Provider struct {
+	// Name is "projects", "git" or "zoxide".
+	Name    string `mapstructure:"name"`
+	Enabled *bool  `mapstructure:"enabled"`
+	// Icon and Label replace the icon and source label shown for its locations.
+	Icon  string `mapstructure:"icon"`
+	Label string `mapstructure:"label"`
+	// Limit is the most zoxide directories to list; 0 lists all of them.
+	Limit int `mapstructure:"limit"`
+	// MinScore hides zoxide directories with a lower frecency score.
+	MinScore float64 `mapstructure:"min-score"`
+	// Exclude lists path globs of zoxide directories to hide.
+	Exclude []string `mapstructure:"exclude"`
+	// Timeout is how long the provider may take, e.g. "2s"; defaults to 5s.
+	Timeout string `mapstructure:"timeout"`
+}
+
+// Git configures the scan for git repositories under root directories.
+type Git struct {
+	// Roots are the directories to scan, e.g. "~/dev".
+	Roots []string `mapstructure:"roots"`
+	// MaxDepth is how many directories below a root to look; defaults to 3.
+	MaxDepth int `mapstructure:"max-depth"`
+	// Ignore lists directory name or path globs that are not scanned.
+	Ignore []string `mapstructure:"ignore"`
+	// Parallel is the number of directories read at once; defaults to the CPU count.
+	Parallel int `mapstructure:"parallel"`
+}
+
+// GC holds settings for idle session garbage collection.
+type GC struct {
+	// MaxIdle is how long a session may go without an attach, e.g. "72h".
+	MaxIdle string `mapstructure:"max-idle"`
+	// Keep lists session name globs or project names that are never collected.
+	Keep []string `mapstructure:"keep"`
 }
 
 // Theme holds color settings for the UI.
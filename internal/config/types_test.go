@@ -7,11 +7,11 @@ import (
 )
 
 func TestConfig_Mapstructure(t *testing.T) {
-	// This test verifies that the struct tags (mapstructure:"...") match 
+	// This test verifies that the struct tags (mapstructure:"...") match
 	// the keys used in configuration files.
 	data := map[string]any{
-		"editor":         "vscode",
-		"shell-default":  true,
+		"editor":        "vscode",
+		"shell-default": true,
 		"theme": map[string]any{
 			"primary": "#000000",
 		},
@@ -21,12 +21,22 @@ func TestConfig_Mapstructure(t *testing.T) {
 				"path":            "/home/user/prj1",
 				"default-actions": false,
 				"shell-default":   true,
+				"session-backend": "tmux",
+				"env":             map[string]any{"NODE_ENV": "development"},
+				"env-files":       []string{".env"},
+				"hooks": map[string]any{
+					"on-create":   "npm install",
+					"post-detach": "echo bye",
+				},
 			},
 		},
 		"actions": []map[string]any{
 			{
 				"name":    "build",
 				"command": "go build",
+				"hooks": map[string]any{
+					"pre-attach": "make deps",
+				},
 			},
 		},
 	}
@@ -46,7 +56,7 @@ func TestConfig_Mapstructure(t *testing.T) {
 	if cfg.Theme.Primary != "#000000" {
 		t.Errorf("expected Theme.Primary to be #000000, got %s", cfg.Theme.Primary)
 	}
-	
+
 	if len(cfg.Projects) != 1 {
 		t.Fatalf("expected 1 project, got %d", len(cfg.Projects))
 	}
@@ -60,8 +70,20 @@ func TestConfig_Mapstructure(t *testing.T) {
 	if p.ShellDefault == nil || *p.ShellDefault != true {
 		t.Errorf("expected project ShellDefault to be true")
 	}
+	if p.SessionBackend != "tmux" {
+		t.Errorf("expected project SessionBackend to be tmux, got %s", p.SessionBackend)
+	}
+	if p.Env["NODE_ENV"] != "development" || len(p.EnvFiles) != 1 {
+		t.Errorf("unexpected project env: %v %v", p.Env, p.EnvFiles)
+	}
+	if p.Hooks.OnCreate != "npm install" || p.Hooks.PostDetach != "echo bye" {
+		t.Errorf("unexpected project hooks: %+v", p.Hooks)
+	}
 
 	if len(cfg.Actions) != 1 || cfg.Actions[0].Name != "build" {
 		t.Errorf("expected 1 action 'build', got %v", cfg.Actions)
 	}
+	if len(cfg.Actions) == 1 && cfg.Actions[0].Hooks.PreAttach != "make deps" {
+		t.Errorf("expected action pre-attach hook, got %+v", cfg.Actions[0].Hooks)
+	}
 }
@@ -2,6 +2,12 @@ package config
 
 import (
 	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
 )
 
 // Validate checks the configuration for errors.
@@ -13,6 +19,209 @@ func (c *Config) Validate() error {
 		if p.Path == "" {
 			return fmt.Errorf("project '%s' missing path", p.Name)
 		}
+		if err := validateSessionBackend(p.SessionBackend); err != nil {
+			return fmt.Errorf("project '%s': %w", p.Name, err)
+		}
+		for _, a := range p.Actions {
+			if err := a.Validate(); err != nil {
+				return fmt.Errorf("project '%s': %w", p.Name, err)
+			}
+		}
+		actions := p.Actions
+		if p.UseDefaultActions() {
+			actions = MergeActions(c.Actions, p.Actions)
+		}
+		for _, l := range p.Layouts {
+			if err := l.Validate(actions); err != nil {
+				return fmt.Errorf("project '%s': %w", p.Name, err)
+			}
+		}
+	}
+	for _, a := range c.Actions {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := validateSessionBackend(c.SessionBackend); err != nil {
+		return err
+	}
+	if err := validateSessionName(c.SessionName); err != nil {
+		return err
+	}
+	for i, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("rule at index %d: %w", i, err)
+		}
+	}
+	seen := make(map[string]bool)
+	for i, p := range c.Providers {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("provider at index %d: %w", i, err)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("provider %q is listed more than once", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	if c.Git.MaxDepth < 0 || c.Git.Parallel < 0 {
+		return fmt.Errorf("git max-depth and parallel must not be negative")
+	}
+	for _, g := range c.Git.Ignore {
+		if _, err := path.Match(g, ""); err != nil {
+			return fmt.Errorf("invalid git ignore pattern %q: %w", g, err)
+		}
+	}
+	if _, err := c.GC.GetMaxIdle(); err != nil {
+		return err
+	}
+	for _, k := range c.GC.Keep {
+		if _, err := path.Match(k, ""); err != nil {
+			return fmt.Errorf("invalid gc keep pattern %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// validateSessionBackend checks that a configured backend name is known.
+// An empty name selects DefaultSessionBackend.
+func validateSessionBackend(name string) error {
+	if name == "" || slices.Contains(SessionBackends, strings.ToLower(name)) {
+		return nil
+	}
+	return fmt.Errorf("unknown session-backend %q, want one of %s", name, strings.Join(SessionBackends, ", "))
+}
+
+// validateSessionName checks that the session-name template parses and only
+// uses SessionNameFields.
+func validateSessionName(text string) error {
+	if text == "" {
+		return nil
+	}
+	tmpl, err := template.New("session-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid session-name template: %w", err)
+	}
+	data := make(map[string]string, len(SessionNameFields))
+	for _, f := range SessionNameFields {
+		data[f] = ""
+	}
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		return fmt.Errorf("invalid session-name template: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that an action runs either a command or args, not both,
+// and that its params are well-formed.
+func (a Action) Validate() error {
+	if a.Command != "" && len(a.Args) > 0 {
+		return fmt.Errorf("action '%s' sets both command and args", a.Name)
+	}
+	if a.When != nil {
+		for _, h := range a.When.Host {
+			if _, err := path.Match(h, ""); err != nil {
+				return fmt.Errorf("action '%s': invalid host pattern %q: %w", a.Name, h, err)
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for i, p := range a.Params {
+		if p.Name == "" {
+			return fmt.Errorf("action '%s' param at index %d missing name", a.Name, i)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("action '%s' declares param '%s' twice", a.Name, p.Name)
+		}
+		seen[p.Name] = true
+		if p.Default != "" && len(p.Choices) > 0 && !slices.Contains(p.Choices, p.Default) {
+			return fmt.Errorf("action '%s' param '%s': default %q is not one of its choices", a.Name, p.Name, p.Default)
+		}
+	}
+	return nil
+}
+
+// Validate checks that a rule has exactly one valid pattern and a valid
+// name template.
+func (r Rule) Validate() error {
+	switch {
+	case r.Match == "" && r.Regex == "":
+		return fmt.Errorf("rule needs match or regex")
+	case r.Match != "" && r.Regex != "":
+		return fmt.Errorf("rule sets both match and regex")
+	case r.Match != "":
+		if _, err := path.Match(r.Match, ""); err != nil {
+			return fmt.Errorf("invalid match pattern %q: %w", r.Match, err)
+		}
+	default:
+		if _, err := regexp.Compile(r.Regex); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", r.Regex, err)
+		}
+	}
+	if r.Name != "" {
+		if _, err := template.New("rule-name").Parse(r.Name); err != nil {
+			return fmt.Errorf("invalid name template: %w", err)
+		}
+	}
+	for _, a := range r.Actions {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks that a layout is named and every tab has panes. Panes run
+// without a way to ask for input, so panes naming one of the given actions
+// must not need a param that has no default.
+func (l Layout) Validate(actions []Action) error {
+	if l.Name == "" {
+		return fmt.Errorf("layout missing name")
+	}
+	if len(l.Tabs) == 0 {
+		return fmt.Errorf("layout '%s' has no tabs", l.Name)
+	}
+	for i, tab := range l.Tabs {
+		if len(tab.Panes) == 0 {
+			return fmt.Errorf("layout '%s' tab %d has no panes", l.Name, i+1)
+		}
+		switch tab.Split {
+		case "", "right", "down":
+		default:
+			return fmt.Errorf("layout '%s' tab %d: split must be \"right\" or \"down\", got %q", l.Name, i+1, tab.Split)
+		}
+		for _, pane := range tab.Panes {
+			for _, a := range actions {
+				if !strings.EqualFold(a.Name, pane) {
+					continue
+				}
+				if missing := a.RequiredParams(); len(missing) > 0 {
+					return fmt.Errorf("layout '%s' pane '%s' needs a default for param '%s'", l.Name, pane, missing[0])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks that a provider is known and its options are valid.
+func (p Provider) Validate() error {
+	switch p.Name {
+	case ProviderProjects, ProviderGit, ProviderZoxide:
+	case "":
+		return fmt.Errorf("missing name")
+	default:
+		return fmt.Errorf("unknown provider %q", p.Name)
+	}
+	if p.Limit < 0 || p.MinScore < 0 {
+		return fmt.Errorf("provider %q: limit and min-score must not be negative", p.Name)
+	}
+	if _, err := p.GetTimeout(); err != nil {
+		return fmt.Errorf("provider %q: %w", p.Name, err)
+	}
+	for _, e := range p.Exclude {
+		if _, err := path.Match(e, ""); err != nil {
+			return fmt.Errorf("provider %q: invalid exclude pattern %q: %w", p.Name, e, err)
+		}
 	}
 	return nil
 }
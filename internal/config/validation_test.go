@@ -38,6 +38,170 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Action With Command And Args",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", Actions: []Action{
+						{Name: "logs", Command: "tail -f app.log", Args: []string{"tail", "-f", "app.log"}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Session Backends",
+			config: Config{
+				SessionBackend: "TMUX",
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", SessionBackend: "shpool"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Unknown Session Backend",
+			config:  Config{SessionBackend: "screen"},
+			wantErr: true,
+		},
+		{
+			name: "Unknown Project Session Backend",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", SessionBackend: "zmx2"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Session Name Template",
+			config:  Config{SessionName: `{{.Host}}-{{.Project}}{{if ne .Action "shell"}}:{{.Action}}{{end}}`},
+			wantErr: false,
+		},
+		{
+			name:    "Session Name Bad Template",
+			config:  Config{SessionName: "{{.Project"},
+			wantErr: true,
+		},
+		{
+			name:    "Session Name Unknown Field",
+			config:  Config{SessionName: "{{.Projcet}}:{{.Action}}"},
+			wantErr: true,
+		},
+		{
+			name: "Rule Without Pattern",
+			config: Config{
+				Rules: []Rule{{Name: "{{.Dir}}"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rule Bad Regex",
+			config: Config{
+				Rules: []Rule{{Regex: "(unclosed"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid Layout",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", Layouts: []Layout{
+						{Name: "dev", Tabs: []LayoutTab{{Panes: []string{"Editor"}}, {Split: "down", Panes: []string{"Server", "Tests"}}}},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Layout Bad Split",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", Layouts: []Layout{
+						{Name: "dev", Tabs: []LayoutTab{{Split: "diagonal", Panes: []string{"Editor"}}}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Layout Pane With Required Param",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", Actions: []Action{
+						{Name: "Tests", Command: "go test {{.Params.pkg}}", Params: []Param{{Name: "pkg"}}},
+					}, Layouts: []Layout{
+						{Name: "dev", Tabs: []LayoutTab{{Panes: []string{"tests"}}}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Layout Without Panes",
+			config: Config{
+				Projects: []Project{
+					{Name: "p1", Path: "/tmp", Layouts: []Layout{{Name: "dev", Tabs: []LayoutTab{{}}}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid GC",
+			config: Config{
+				GC: GC{MaxIdle: "72h", Keep: []string{"dotfiles:*"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid GC Max Idle",
+			config: Config{
+				GC: GC{MaxIdle: "3 days"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid GC Keep Pattern",
+			config: Config{
+				GC: GC{Keep: []string{"["}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Valid Providers",
+			config: Config{
+				Providers: []Provider{{Name: "zoxide", Limit: 20, Exclude: []string{"/tmp/**"}}, {Name: "projects"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown Provider",
+			config: Config{
+				Providers: []Provider{{Name: "fasd"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Duplicate Provider",
+			config: Config{
+				Providers: []Provider{{Name: "zoxide"}, {Name: "zoxide"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Negative Provider Limit",
+			config: Config{
+				Providers: []Provider{{Name: "zoxide", Limit: -1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Invalid Provider Timeout",
+			config: Config{
+				Providers: []Provider{{Name: "git", Timeout: "soon"}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,37 @@
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"os"
+	"path/filepath"
+)
+
+// conventionActions returns the standard commands of Cargo and Go projects.
+// They only depend on which files exist, so they are not cached.
+func conventionActions(dir string) []config.Action {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	var actions []config.Action
+	if exists("Cargo.toml") {
+		actions = append(actions,
+			config.Action{Name: "cargo build", Command: "cargo build"},
+			config.Action{Name: "cargo test", Command: "cargo test"},
+		)
+		if exists(filepath.Join("src", "main.rs")) {
+			actions = append(actions, config.Action{Name: "cargo run", Command: "cargo run"})
+		}
+	}
+	if exists("go.mod") {
+		actions = append(actions,
+			config.Action{Name: "go build", Command: "go build ./..."},
+			config.Action{Name: "go test", Command: "go test ./..."},
+		)
+		if exists("main.go") {
+			actions = append(actions, config.Action{Name: "go run", Command: "go run ."})
+		}
+	}
+	return actions
+}
@@ -0,0 +1,159 @@
+// Package discover finds the tasks a directory already defines, such as
+// Makefile targets and package.json scripts, and offers them as actions.
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/utils"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// source reads the tasks defined in one kind of file.
+type source struct {
+	// files are the names the file may have, in order of preference.
+	files []string
+	// parse returns the task names defined in the file.
+	parse func(path string) ([]string, error)
+	// action builds the action running a task in dir.
+	action func(dir, task string) config.Action
+}
+
+// sources are checked in order; each contributes tasks from the first of its
+// files that exists.
+var sources = []source{
+	makefileSource,
+	justfileSource,
+	npmSource,
+	taskfileSource,
+}
+
+// entry is the cached result of parsing a file.
+type entry struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Tasks   []string  `json:"tasks"`
+}
+
+// Discoverer finds actions in directories, caching parsed files by
+// modification time and size.
+type Discoverer struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]entry
+	loaded  bool
+	dirty   bool
+}
+
+// New creates a Discoverer whose cache is stored in cacheFile.
+// An empty cacheFile keeps the cache in memory only.
+func New(cacheFile string) *Discoverer {
+	return &Discoverer{path: cacheFile}
+}
+
+// NewDefault creates a Discoverer caching in the atelier-go state directory.
+func NewDefault() *Discoverer {
+	dir, err := utils.GetStateSubdir("cache")
+	if err != nil {
+		return New("")
+	}
+	return New(filepath.Join(dir, "actions.json"))
+}
+
+// Actions returns the tasks defined in dir as actions, marked as discovered.
+// Files that cannot be read or parsed are skipped.
+func (d *Discoverer) Actions(dir string) []config.Action {
+	var actions []config.Action
+	for _, src := range sources {
+		for _, name := range src.files {
+			path := filepath.Join(dir, name)
+			tasks, ok := d.tasks(path, src.parse)
+			if !ok {
+				continue
+			}
+			for _, t := range tasks {
+				act := src.action(dir, t)
+				act.Discovered = true
+				actions = append(actions, act)
+			}
+			break
+		}
+	}
+	for _, act := range conventionActions(dir) {
+		act.Discovered = true
+		actions = append(actions, act)
+	}
+	return actions
+}
+
+// tasks returns the tasks in a file, parsing it only when it changed since
+// it was cached. It reports false when the file does not exist or cannot be parsed.
+func (d *Discoverer) tasks(path string, parse func(string) ([]string, error)) ([]string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return nil, false
+	}
+
+	d.mu.Lock()
+	d.load()
+	e, ok := d.entries[path]
+	d.mu.Unlock()
+	if ok && e.ModTime.Equal(info.ModTime()) && e.Size == info.Size() {
+		return e.Tasks, true
+	}
+
+	tasks, err := parse(path)
+	if err != nil {
+		return nil, false
+	}
+
+	d.mu.Lock()
+	d.entries[path] = entry{ModTime: info.ModTime(), Size: info.Size(), Tasks: tasks}
+	d.dirty = true
+	d.mu.Unlock()
+	return tasks, true
+}
+
+// load reads the cache file once. The caller must hold d.mu.
+func (d *Discoverer) load() {
+	if d.loaded {
+		return
+	}
+	d.loaded = true
+	d.entries = make(map[string]entry)
+	if d.path == "" {
+		return
+	}
+	data, err := os.ReadFile(d.path)
+	if err != nil {
+		return
+	}
+	// A corrupt cache is rebuilt from scratch
+	_ = json.Unmarshal(data, &d.entries)
+}
+
+// Save writes the cache if anything changed since it was loaded.
+func (d *Discoverer) Save() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.dirty || d.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(d.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode action cache: %w", err)
+	}
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write action cache: %w", err)
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return fmt.Errorf("failed to write action cache: %w", err)
+	}
+	d.dirty = false
+	return nil
+}
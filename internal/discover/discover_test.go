@@ -0,0 +1,128 @@
+package discover
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Makefile", `CC := gcc
+VERSION ?= 1.0
+.PHONY: build test
+build test: deps
+	$(CC) -o app main.c
+deps:
+	@echo deps
+%.o: %.c
+bin/app: build
+`)
+	writeFile(t, dir, "justfile", `set shell := ["bash", "-c"]
+alias b := build
+version := "1.0"
+
+# Build the app
+build target='debug':
+    cargo build
+
+[private]
+helper:
+    echo hidden
+
+_internal:
+    echo hidden
+
+@lint:
+    cargo clippy
+`)
+	writeFile(t, dir, "package.json", `{"name": "web", "scripts": {"dev": "vite", "build": "vite build", "test": "vitest"}}`)
+	writeFile(t, dir, "Taskfile.yml", `version: '3'
+tasks:
+  serve:
+    cmds: [go run .]
+  setup:
+    internal: true
+    cmds: [go mod download]
+  fmt: gofmt -w .
+`)
+
+	tests := []struct {
+		file  string
+		parse func(string) ([]string, error)
+		want  []string
+	}{
+		{"Makefile", parseMakefile, []string{"build", "test", "deps"}},
+		{"justfile", parseJustfile, []string{"build", "lint"}},
+		{"package.json", parsePackageJSON, []string{"dev", "build", "test"}},
+		{"Taskfile.yml", parseTaskfile, []string{"serve", "fmt"}},
+	}
+	for _, tt := range tests {
+		got, err := tt.parse(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatalf("parsing %s failed: %v", tt.file, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestDiscovererActions(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "package.json", `{"scripts": {"dev": "vite"}}`)
+	writeFile(t, dir, "pnpm-lock.yaml", "")
+	writeFile(t, dir, "go.mod", "module x\n")
+
+	cache := filepath.Join(t.TempDir(), "actions.json")
+	d := New(cache)
+	var names, commands []string
+	for _, a := range d.Actions(dir) {
+		if !a.Discovered {
+			t.Errorf("expected %q to be marked as discovered", a.Name)
+		}
+		names = append(names, a.Name)
+		commands = append(commands, a.Command)
+	}
+	if want := []string{"pnpm dev", "go build", "go test"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	if commands[0] != "pnpm run dev" {
+		t.Errorf("expected the lockfile to pick pnpm, got %q", commands[0])
+	}
+	if err := d.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// A fresh discoverer reuses the cached scripts while the file is unchanged
+	pkg := filepath.Join(dir, "package.json")
+	info, _ := os.Stat(pkg)
+	d = New(cache)
+	d.load()
+	d.entries[pkg] = entry{ModTime: info.ModTime(), Size: info.Size(), Tasks: []string{"cached"}}
+	if got := d.Actions(dir)[0].Name; got != "pnpm cached" {
+		t.Errorf("expected the cached entry to be used, got %q", got)
+	}
+
+	// Changing the file invalidates the entry
+	writeFile(t, dir, "package.json", `{"scripts": {"start": "node ."}}`)
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(pkg, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Actions(dir)[0].Name; got != "pnpm start" {
+		t.Errorf("expected the changed file to be parsed again, got %q", got)
+	}
+}
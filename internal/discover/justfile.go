@@ -0,0 +1,58 @@
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var justfileSource = source{
+	files: []string{"justfile", "Justfile", ".justfile"},
+	parse: parseJustfile,
+	action: func(dir, task string) config.Action {
+		return config.Action{Name: "just " + task, Command: "just " + task}
+	},
+}
+
+// justRecipe matches recipe headers such as "build target='x': deps".
+var justRecipe = regexp.MustCompile(`^@?([A-Za-z][A-Za-z0-9_-]*)(\s[^:]*)?:([^=]|$)`)
+
+// justKeywords start lines that look like recipes but are not.
+var justKeywords = map[string]bool{"set": true, "alias": true, "export": true, "import": true, "mod": true}
+
+// parseJustfile returns the public recipes of a justfile. Recipes starting
+// with "_" or marked [private] are skipped.
+func parseJustfile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var recipes []string
+	private := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "[") {
+			// Attributes apply to the next recipe
+			private = private || strings.Contains(line, "private")
+			continue
+		}
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' {
+			continue
+		}
+		m := justRecipe.FindStringSubmatch(line)
+		if m == nil || justKeywords[m[1]] {
+			private = false
+			continue
+		}
+		if !private {
+			recipes = append(recipes, m[1])
+		}
+		private = false
+	}
+	return recipes, scanner.Err()
+}
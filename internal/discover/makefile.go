@@ -0,0 +1,53 @@
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var makefileSource = source{
+	files: []string{"GNUmakefile", "makefile", "Makefile"},
+	parse: parseMakefile,
+	action: func(dir, task string) config.Action {
+		return config.Action{Name: "make " + task, Command: "make " + task}
+	},
+}
+
+// makeRule matches rule lines such as "build test: deps", but not variable
+// assignments like "CC := gcc".
+var makeRule = regexp.MustCompile(`^([A-Za-z0-9][^:=#\s]*(?:\s+[A-Za-z0-9][^:=#\s]*)*)\s*:([^=]|$)`)
+
+// parseMakefile returns the explicit targets of a Makefile, skipping special
+// targets like .PHONY, pattern rules and file targets in subdirectories.
+func parseMakefile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var targets []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '\t' || line[0] == ' ' {
+			continue
+		}
+		m := makeRule.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, t := range strings.Fields(m[1]) {
+			if strings.ContainsAny(t, "%$/") || seen[t] {
+				continue
+			}
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	return targets, scanner.Err()
+}
@@ -0,0 +1,75 @@
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var npmSource = source{
+	files: []string{"package.json"},
+	parse: parsePackageJSON,
+	action: func(dir, task string) config.Action {
+		runner := packageRunner(dir)
+		return config.Action{Name: runner + " " + task, Command: runner + " run " + task}
+	},
+}
+
+// packageRunner picks the package manager from the lockfile, defaulting to npm.
+func packageRunner(dir string) string {
+	for _, r := range []struct{ lockfile, runner string }{
+		{"pnpm-lock.yaml", "pnpm"},
+		{"yarn.lock", "yarn"},
+		{"bun.lockb", "bun"},
+		{"bun.lock", "bun"},
+	} {
+		if _, err := os.Stat(filepath.Join(dir, r.lockfile)); err == nil {
+			return r.runner
+		}
+	}
+	return "npm"
+}
+
+// parsePackageJSON returns the names of the scripts in package.json, in the
+// order they are defined.
+func parsePackageJSON(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Scripts json.RawMessage `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(pkg.Scripts) == 0 {
+		return nil, nil
+	}
+	return objectKeys(pkg.Scripts)
+}
+
+// objectKeys returns the keys of a JSON object in document order.
+func objectKeys(raw json.RawMessage) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("scripts is not an object")
+	}
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		keys = append(keys, key)
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,49 @@
+package discover
+
+import (
+	"atelier-go/internal/config"
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+)
+
+var taskfileSource = source{
+	files: []string{"Taskfile.yml", "Taskfile.yaml", "taskfile.yml", "taskfile.yaml"},
+	parse: parseTaskfile,
+	action: func(dir, task string) config.Action {
+		return config.Action{Name: "task " + task, Command: "task " + task}
+	},
+}
+
+// parseTaskfile returns the tasks of a Taskfile in the order they are
+// defined, skipping internal ones.
+func parseTaskfile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Tasks yaml.Node `yaml:"tasks"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if doc.Tasks.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var tasks []string
+	content := doc.Tasks.Content
+	for i := 0; i+1 < len(content); i += 2 {
+		var body struct {
+			Internal bool `yaml:"internal"`
+		}
+		// Tasks may also be a bare command string or list
+		_ = content[i+1].Decode(&body)
+		if !body.Internal {
+			tasks = append(tasks, content[i].Value)
+		}
+	}
+	return tasks, nil
+}
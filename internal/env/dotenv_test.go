@@ -0,0 +1,51 @@
+package env
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("ATELIER_TEST_HOME", "/home/user")
+
+	input := `
+# Database settings
+export DB_HOST=localhost
+DB_PORT = 5432 # default port
+DB_URL="postgres://${DB_HOST}:${DB_PORT}/app"
+SECRET='literal ${NOT_EXPANDED}'
+MULTI="line one\nline two"
+CACHE_DIR=$ATELIER_TEST_HOME/.cache
+EMPTY=
+`
+	vars, err := ParseDotenv(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDotenv failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"DB_HOST":   "localhost",
+		"DB_PORT":   "5432",
+		"DB_URL":    "postgres://localhost:5432/app",
+		"SECRET":    "literal ${NOT_EXPANDED}",
+		"MULTI":     "line one\nline two",
+		"CACHE_DIR": "/home/user/.cache",
+		"EMPTY":     "",
+	}
+	if len(vars) != len(expected) {
+		t.Errorf("expected %d variables, got %d: %v", len(expected), len(vars), vars)
+	}
+	for k, want := range expected {
+		if vars[k] != want {
+			t.Errorf("%s: expected %q, got %q", k, want, vars[k])
+		}
+	}
+}
+
+func TestParseDotenv_Errors(t *testing.T) {
+	for _, input := range []string{"NOVALUE", `KEY="unterminated`, "BAD KEY=1"} {
+		if _, err := ParseDotenv(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package locations
+
+import "context"
+
+// DisplayProvider wraps a Provider, setting the icon and label shown for
+// its locations.
+type DisplayProvider struct {
+	Provider
+	icon  string
+	label string
+}
+
+// NewDisplayProvider wraps a provider to override how its locations are shown.
+// Empty values keep the defaults.
+func NewDisplayProvider(p Provider, icon, label string) *DisplayProvider {
+	return &DisplayProvider{Provider: p, icon: icon, label: label}
+}
+
+// Fetch returns the wrapped provider's locations with the icon and label set.
+func (d *DisplayProvider) Fetch(ctx context.Context) ([]Location, error) {
+	locs, err := d.Provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range locs {
+		locs[i].Icon = d.icon
+		locs[i].Label = d.label
+	}
+	return locs, nil
+}
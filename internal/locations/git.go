@@ -0,0 +1,173 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/discover"
+	"atelier-go/internal/utils"
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GitProvider implements Provider for git repositories found under root directories.
+type GitProvider struct {
+	roots          []string
+	maxDepth       int
+	ignore         []string
+	parallel       int
+	defaultActions []config.Action
+	shellDefault   bool
+	discoverer     *discover.Discoverer
+}
+
+// NewGitProvider creates a new GitProvider from the git scan settings.
+// A non-nil discoverer adds the actions found in each repository.
+func NewGitProvider(settings config.Git, defaultActions []config.Action, shellDefault bool, discoverer *discover.Discoverer) *GitProvider {
+	ignore := make([]string, 0, len(settings.Ignore))
+	for _, g := range settings.Ignore {
+		if expanded, err := utils.ExpandPath(g); err == nil {
+			g = expanded
+		}
+		ignore = append(ignore, filepath.ToSlash(g))
+	}
+	return &GitProvider{
+		roots:          settings.Roots,
+		maxDepth:       settings.GetMaxDepth(),
+		ignore:         ignore,
+		parallel:       settings.GetParallel(),
+		defaultActions: defaultActions,
+		shellDefault:   shellDefault,
+		discoverer:     discoverer,
+	}
+}
+
+// Name returns the provider name.
+func (g *GitProvider) Name() string {
+	return "Git"
+}
+
+// Fetch scans the roots for git repositories. Roots that do not exist on
+// this machine are skipped.
+func (g *GitProvider) Fetch(ctx context.Context) ([]Location, error) {
+	var repos []string
+	seen := make(map[string]bool)
+	for _, root := range g.roots {
+		expanded, err := utils.ExpandPath(root)
+		if err != nil {
+			continue
+		}
+		for _, repo := range g.scan(ctx, filepath.Clean(expanded)) {
+			if !seen[repo] {
+				seen[repo] = true
+				repos = append(repos, repo)
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	locations := make([]Location, 0, len(repos))
+	for _, repo := range repos {
+		locations = append(locations, Location{
+			Name:      filepath.Base(repo),
+			Path:      repo,
+			Source:    g.Name(),
+			Actions:   g.actions(repo),
+			actionsAt: g.actions,
+		})
+	}
+	if g.discoverer != nil {
+		// The cache only speeds up later runs, so failing to save it is not fatal
+		_ = g.discoverer.Save()
+	}
+	return locations, nil
+}
+
+// scan walks root up to the maximum depth, reading up to g.parallel
+// directories at once, and returns the sorted repository paths.
+// Repositories are not searched for nested repositories.
+func (g *GitProvider) scan(ctx context.Context, root string) []string {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		repos []string
+		sem   = make(chan struct{}, g.parallel)
+	)
+
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+
+		sem <- struct{}{}
+		entries, err := os.ReadDir(dir)
+		<-sem
+		if err != nil {
+			return
+		}
+
+		for _, e := range entries {
+			// .git is a directory, or a file in worktrees and submodules
+			if e.Name() == ".git" {
+				mu.Lock()
+				repos = append(repos, dir)
+				mu.Unlock()
+				return
+			}
+		}
+		if depth >= g.maxDepth {
+			return
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			child := filepath.Join(dir, e.Name())
+			if g.ignored(child) {
+				continue
+			}
+			wg.Add(1)
+			go walk(child, depth+1)
+		}
+	}
+
+	wg.Add(1)
+	go walk(root, 0)
+	wg.Wait()
+
+	sort.Strings(repos)
+	return repos
+}
+
+// ignored reports whether a directory matches an ignore glob, either by its
+// name or by its full path.
+func (g *GitProvider) ignored(dir string) bool {
+	name := filepath.Base(dir)
+	slashed := filepath.ToSlash(dir)
+	for _, pattern := range g.ignore {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := utils.MatchGlob(pattern, slashed); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// actions builds the actions of a repository checked out in dir.
+func (g *GitProvider) actions(dir string) []config.Action {
+	actions, shellDefault := applyProjectFile(dir, g.defaultActions, g.shellDefault)
+	if g.discoverer != nil {
+		actions = AppendDiscovered(actions, g.discoverer.Actions(dir))
+	}
+	return BuildActionsWithShell(FilterActions(actions, dir), shellDefault)
+}
@@ -0,0 +1,115 @@
+package locations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"atelier-go/internal/config"
+)
+
+func TestGitProvider(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(parts ...string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(append([]string{root}, parts...)...), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir("api", ".git")
+	mkdir("api", "vendor", "lib", ".git") // nested repositories are not searched
+	mkdir("clients", "acme", "site", ".git")
+	mkdir("clients", "too", "deep", "repo", ".git")
+	mkdir("node_modules", "pkg", ".git")
+	mkdir("archive", "old", ".git")
+	mkdir(".cache", "repo", ".git")
+	mkdir("notes")
+	// Worktrees and submodules have a .git file
+	mkdir("tools")
+	if err := os.WriteFile(filepath.Join(root, "tools", ".git"), []byte("gitdir: ../.git/worktrees/tools\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewGitProvider(config.Git{
+		Roots:    []string{root, filepath.Join(root, "missing")},
+		MaxDepth: 3,
+		Ignore:   []string{"node_modules", filepath.Join(root, "archive")},
+		Parallel: 2,
+	}, []config.Action{{Name: "Build", Command: "make"}}, false, nil)
+
+	locs, err := p.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	want := []string{"api", "clients/acme/site", "tools"}
+	if len(locs) != len(want) {
+		t.Fatalf("expected %d repositories, got %+v", len(want), locs)
+	}
+	for i, rel := range want {
+		loc := locs[i]
+		if loc.Path != filepath.Join(root, rel) || loc.Source != "Git" || loc.Name != filepath.Base(rel) {
+			t.Errorf("unexpected location %d: %+v", i, loc)
+		}
+		if len(loc.Actions) != 2 {
+			t.Errorf("expected default actions plus Shell, got %+v", loc.Actions)
+		}
+	}
+}
+
+// staticProvider returns fixed locations.
+type staticProvider struct {
+	name string
+	locs []Location
+}
+
+func (s staticProvider) Name() string { return s.name }
+
+func (s staticProvider) Fetch(ctx context.Context) ([]Location, error) { return s.locs, nil }
+
+func TestManagerPrefersProjectsOverScannedRepos(t *testing.T) {
+	mgr := NewManager(
+		staticProvider{"Project", []Location{{Name: "My API", Path: "/dev/api", Source: "Project"}}},
+		staticProvider{"Git", []Location{{Name: "api", Path: "/dev/api", Source: "Git"}, {Name: "web", Path: "/dev/web", Source: "Git"}}},
+	)
+	locs, failed := mgr.GetAll(context.Background())
+	if len(failed) > 0 {
+		t.Fatalf("GetAll failed: %v", failed)
+	}
+	if len(locs) != 2 || locs[0].Name != "My API" || locs[0].Source != "Project" || locs[1].Name != "web" {
+		t.Errorf("unexpected locations: %+v", locs)
+	}
+}
+
+func TestManagerPrefersProjectsListedAfterGit(t *testing.T) {
+	project := Location{
+		Name:           "My API",
+		Path:           "/dev/api",
+		Source:         "Project",
+		Actions:        []config.Action{{Name: "test", Command: "make test"}},
+		SessionBackend: "tmux",
+		Env:            map[string]string{"PORT": "8080"},
+	}
+	mgr := NewManager(
+		staticProvider{"Git", []Location{{Name: "api", Path: "/dev/api", Source: "Git", Repo: "/dev/api"}, {Name: "web", Path: "/dev/web", Source: "Git"}}},
+		staticProvider{"Project", []Location{project}},
+	)
+	locs, failed := mgr.GetAll(context.Background())
+	if len(failed) > 0 {
+		t.Fatalf("GetAll failed: %v", failed)
+	}
+	if len(locs) != 2 || locs[1].Name != "web" {
+		t.Fatalf("unexpected locations: %+v", locs)
+	}
+	api := locs[0]
+	if api.Name != "My API" || api.Source != "Project" || api.SessionBackend != "tmux" || api.Env["PORT"] != "8080" {
+		t.Errorf("project settings lost: %+v", api)
+	}
+	if len(api.Actions) != 1 || api.Actions[0].Name != "test" {
+		t.Errorf("project actions lost: %+v", api.Actions)
+	}
+	if api.Repo != "/dev/api" {
+		t.Errorf("Repo = %q, want the scanned repository", api.Repo)
+	}
+}
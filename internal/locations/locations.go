@@ -3,12 +3,15 @@ package locations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"atelier-go/internal/config"
+	"atelier-go/internal/output"
 	"atelier-go/internal/utils"
 
 	"github.com/sahilm/fuzzy"
@@ -16,65 +19,188 @@ import (
 
 // Location represents a unified project or directory entry.
 type Location struct {
-	Name    string
-	Path    string
-	Source  string // "Project" or "Zoxide"
-	Actions []config.Action
+	Name    string          `json:"name" yaml:"name"`
+	Path    string          `json:"path" yaml:"path"`
+	Source  string          `json:"source" yaml:"source"` // "Project", "Git" or "Zoxide"
+	Actions []config.Action `json:"actions" yaml:"actions"`
+	// SessionBackend overrides the default session backend when set.
+	SessionBackend string `json:"session_backend,omitempty" yaml:"session_backend,omitempty"`
+	// Hooks are the project-level lifecycle hooks.
+	Hooks config.Hooks `json:"hooks,omitzero" yaml:"hooks,omitempty"`
+	// Layouts arrange several actions in terminal tabs and splits.
+	Layouts []config.Layout `json:"layouts,omitempty" yaml:"layouts,omitempty"`
+	// Env and EnvFiles are the project-level environment variables.
+	Env      map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	EnvFiles []string          `json:"env_files,omitempty" yaml:"env_files,omitempty"`
+	// Repo is the main worktree of the git repository the location belongs to.
+	Repo string `json:"repo,omitempty" yaml:"repo,omitempty"`
+	// Parent is the path of the location a worktree is listed under.
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Icon and Label override how the location's source is shown.
+	Icon  string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+
+	// actionsAt builds the location's actions for another directory with the
+	// same settings, such as a worktree, from that directory's own project
+	// file and tasks.
+	actionsAt func(dir string) []config.Action
 }
 
+// SourceProject is the source of locations configured as projects.
+const SourceProject = "Project"
+
+// SourceLabel returns the label shown for the location's source.
+func (l Location) SourceLabel() string {
+	if l.Label != "" {
+		return l.Label
+	}
+	return l.Source
+}
+
+// DefaultTimeout is how long a provider may take before its locations are
+// left out.
+const DefaultTimeout = 5 * time.Second
+
 // Manager orchestrates location providers.
 type Manager struct {
 	providers []Provider
+	timeouts  []time.Duration // per provider; zero uses timeout
+	timeout   time.Duration
 }
 
 // NewManager creates a new Manager with the given providers.
 func NewManager(providers ...Provider) *Manager {
-	return &Manager{providers: providers}
+	return &Manager{
+		providers: providers,
+		timeouts:  make([]time.Duration, len(providers)),
+		timeout:   DefaultTimeout,
+	}
+}
+
+// AddProvider appends a provider with its own fetch timeout. Zero uses the
+// Manager's timeout and less than zero disables the limit.
+func (m *Manager) AddProvider(p Provider, timeout time.Duration) {
+	m.providers = append(m.providers, p)
+	m.timeouts = append(m.timeouts, timeout)
+}
+
+// SetTimeout sets how long each provider may take, unless it was added with
+// its own timeout. Zero or less disables the limit.
+func (m *Manager) SetTimeout(d time.Duration) {
+	m.timeout = d
+}
+
+// ProviderError reports a provider whose locations were left out.
+type ProviderError struct {
+	Provider string
+	Err      error
+}
+
+// Error implements error.
+func (e ProviderError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Provider, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e ProviderError) Unwrap() error {
+	return e.Err
 }
 
 // GetAll returns a merged list of locations from all providers.
-// It deduplicates paths, giving priority to earlier providers in the list.
-func (m *Manager) GetAll(ctx context.Context) ([]Location, error) {
+// Each path is listed once, in the place of the first provider that returned
+// it, but a configured project's settings win over a scanned entry for the
+// same path whatever the provider order. Otherwise earlier providers win.
+// Providers that fail or time out are left out and reported, so the others
+// are still usable.
+func (m *Manager) GetAll(ctx context.Context) ([]Location, []ProviderError) {
 	var allLocations []Location
-	seenPaths := make(map[string]bool)
+	seenPaths := make(map[string]int)
 	var wg sync.WaitGroup
 
 	results := make([][]Location, len(m.providers))
-	errors := make([]error, len(m.providers))
+	errs := make([]error, len(m.providers))
 
 	for i, p := range m.providers {
 		wg.Add(1)
 		go func(index int, provider Provider) {
 			defer wg.Done()
-			locs, err := provider.Fetch(ctx)
-			if err != nil {
-				errors[index] = err
-				return
+			timeout := m.timeouts[index]
+			if timeout == 0 {
+				timeout = m.timeout
 			}
-			results[index] = locs
+			results[index], errs[index] = fetch(ctx, provider, timeout)
 		}(i, p)
 	}
 
 	wg.Wait()
 
-	// Check for errors from any provider
-	for _, err := range errors {
-		if err != nil {
-			return nil, fmt.Errorf("provider failed: %w", err)
-		}
-	}
-
 	// Merge results in order
-	for _, locs := range results {
+	var failed []ProviderError
+	for i, locs := range results {
+		if errs[i] != nil {
+			failed = append(failed, ProviderError{Provider: m.providers[i].Name(), Err: errs[i]})
+			continue
+		}
 		for _, loc := range locs {
-			if !seenPaths[loc.Path] {
+			j, ok := seenPaths[loc.Path]
+			if !ok {
+				seenPaths[loc.Path] = len(allLocations)
 				allLocations = append(allLocations, loc)
-				seenPaths[loc.Path] = true
+				continue
+			}
+			if loc.Source == SourceProject && allLocations[j].Source != SourceProject {
+				allLocations[j] = mergeProject(allLocations[j], loc)
 			}
 		}
 	}
 
-	return allLocations, nil
+	return allLocations, failed
+}
+
+// mergeProject returns a configured project's location in place of a
+// scanned one for the same path, keeping the repository details the scan
+// found when the project has none.
+func mergeProject(scanned, project Location) Location {
+	if project.Repo == "" {
+		project.Repo = scanned.Repo
+	}
+	if project.Parent == "" {
+		project.Parent = scanned.Parent
+	}
+	return project
+}
+
+// fetch runs one provider, giving up once the timeout passes even if the
+// provider does not stop on its own. Zero or less disables the limit.
+func fetch(ctx context.Context, p Provider, timeout time.Duration) ([]Location, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		locs []Location
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		locs, err := p.Fetch(ctx)
+		done <- result{locs, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		return r.locs, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		return nil, ctx.Err()
+	}
 }
 
 // locationSource implements fuzzy.Source for location matching.
@@ -85,28 +211,50 @@ func (s locationSource) Len() int            { return len(s) }
 
 // Find searches for a location by name. It first tries an exact case-insensitive
 // match, then falls back to fuzzy matching if no exact match is found.
-func (m *Manager) Find(ctx context.Context, name string) (*Location, error) {
-	locs, err := m.GetAll(ctx)
-	if err != nil {
-		return nil, err
+// Providers that failed are returned alongside the match, since the location
+// asked for may have been among their results.
+func (m *Manager) Find(ctx context.Context, name string) (*Location, []ProviderError, error) {
+	locs, failed := m.GetAll(ctx)
+	if loc, err := Lookup(locs, name); err == nil {
+		return loc, failed, nil
 	}
 
-	// Try exact match first
+	if len(failed) > 0 {
+		errs := make([]error, len(failed))
+		for i, f := range failed {
+			errs[i] = f
+		}
+		return nil, failed, fmt.Errorf("location %q not found; some providers failed: %w", name, errors.Join(errs...))
+	}
+	return nil, nil, fmt.Errorf("location %q not found", name)
+}
+
+// Lookup finds a location by name among locs, preferring an exact
+// case-insensitive match over the best fuzzy one.
+func Lookup(locs []Location, name string) (*Location, error) {
 	for _, loc := range locs {
 		if strings.EqualFold(loc.Name, name) {
 			return &loc, nil
 		}
 	}
 
-	// Fallback to fuzzy match
 	matches := fuzzy.FindFrom(name, locationSource(locs))
 	if len(matches) > 0 {
 		return &locs[matches[0].Index], nil
 	}
-
 	return nil, fmt.Errorf("location %q not found", name)
 }
 
+// LookupPath returns the location in locs with the given directory, or nil.
+func LookupPath(locs []Location, path string) *Location {
+	for _, loc := range locs {
+		if samePath(loc.Path, path) {
+			return &loc
+		}
+	}
+	return nil
+}
+
 // PrintTable formats and prints the locations to the provided writer in a table format.
 func PrintTable(w io.Writer, locs []Location) error {
 	headers := []string{"SOURCE", "NAME", "PATH", "ACTIONS"}
@@ -118,12 +266,46 @@ func PrintTable(w io.Writer, locs []Location) error {
 		if actionCount > 0 {
 			actionStr = fmt.Sprintf("%d", actionCount)
 		}
-		rows = append(rows, []string{loc.Source, loc.Name, loc.Path, actionStr})
+		rows = append(rows, []string{loc.SourceLabel(), loc.Name, loc.Path, actionStr})
 	}
 
 	return utils.RenderTable(w, headers, rows)
 }
 
+// Renderer describes how locations are printed in each output format.
+var Renderer = output.Renderer[Location]{
+	Kind:    "locations",
+	Table:   PrintTable,
+	Headers: []string{"SOURCE", "NAME", "PATH", "ACTIONS", "SESSION_BACKEND"},
+	Row: func(loc Location) []string {
+		names := make([]string, len(loc.Actions))
+		for i, a := range loc.Actions {
+			names[i] = a.Name
+		}
+		return []string{loc.SourceLabel(), loc.Name, loc.Path, strings.Join(names, ","), loc.SessionBackend}
+	},
+}
+
+// AppendDiscovered adds discovered actions after the configured ones, skipping
+// any whose name is already taken by a configured action.
+func AppendDiscovered(actions, discovered []config.Action) []config.Action {
+	if len(discovered) == 0 {
+		return actions
+	}
+	taken := make(map[string]bool, len(actions))
+	for _, a := range actions {
+		taken[utils.Sanitize(a.Name)] = true
+	}
+	merged := append([]config.Action(nil), actions...)
+	for _, a := range discovered {
+		if key := utils.Sanitize(a.Name); !taken[key] {
+			taken[key] = true
+			merged = append(merged, a)
+		}
+	}
+	return merged
+}
+
 // BuildActionsWithShell constructs the final action list, positioning "Shell"
 // correctly based on the shellDefault setting. It ensures no duplicate "Shell" action
 // and avoids mutating the input slice.
@@ -0,0 +1,75 @@
+package locations
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingProvider always fails to fetch.
+type failingProvider struct{ name string }
+
+func (f failingProvider) Name() string { return f.name }
+
+func (f failingProvider) Fetch(ctx context.Context) ([]Location, error) {
+	return nil, errors.New("zoxide: executable file not found")
+}
+
+// stuckProvider ignores its context and never returns.
+type stuckProvider struct{}
+
+func (stuckProvider) Name() string { return "Stuck" }
+
+func (stuckProvider) Fetch(ctx context.Context) ([]Location, error) {
+	select {}
+}
+
+func TestManagerToleratesFailingProviders(t *testing.T) {
+	mgr := NewManager(
+		staticProvider{"Project", []Location{{Name: "api", Path: "/dev/api", Source: "Project"}}},
+		failingProvider{"Zoxide"},
+	)
+	mgr.SetTimeout(0)
+	// The provider's own timeout applies however it is wrapped
+	mgr.AddProvider(NewDisplayProvider(stuckProvider{}, "", "Stuck"), 20*time.Millisecond)
+
+	start := time.Now()
+	locs, failed := mgr.GetAll(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the stuck provider to time out, took %s", elapsed)
+	}
+	if len(locs) != 1 || locs[0].Name != "api" {
+		t.Errorf("expected the working provider's locations, got %+v", locs)
+	}
+	if len(failed) != 2 || failed[0].Provider != "Zoxide" || failed[1].Provider != "Stuck" {
+		t.Fatalf("expected Zoxide and Stuck to be reported, got %v", failed)
+	}
+	if !strings.Contains(failed[1].Error(), "timed out") {
+		t.Errorf("expected a timeout, got %v", failed[1])
+	}
+
+	if _, _, err := mgr.Find(context.Background(), "web"); err == nil || !strings.Contains(err.Error(), "Zoxide") {
+		t.Errorf("expected a not found error naming the failed provider, got %v", err)
+	}
+
+	// A fuzzy match among partial results must still report what is missing
+	loc, failed, err := mgr.Find(context.Background(), "ap")
+	if err != nil || loc.Name != "api" {
+		t.Fatalf("expected a fuzzy match on api, got %v, %v", loc, err)
+	}
+	if len(failed) != 2 {
+		t.Errorf("expected the failed providers with the match, got %v", failed)
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	locs := []Location{{Name: "api", Path: "/src/api"}, {Name: "api", Path: "/src/other/api"}}
+	if loc := LookupPath(locs, "/src/other/api"); loc == nil || loc.Path != "/src/other/api" {
+		t.Errorf("expected the location with that path, got %+v", loc)
+	}
+	if loc := LookupPath(locs, "/src/web"); loc != nil {
+		t.Errorf("expected no location, got %+v", loc)
+	}
+}
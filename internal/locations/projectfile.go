@@ -0,0 +1,19 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+)
+
+// applyProjectFile merges the actions of the .atelier.yaml in dir over the
+// given ones, and returns its shell-default if it sets one. A missing or
+// unreadable file leaves the location unchanged.
+func applyProjectFile(dir string, actions []config.Action, shellDefault bool) ([]config.Action, bool) {
+	pf, err := config.LoadProjectFile(dir)
+	if err != nil || pf == nil {
+		return actions, shellDefault
+	}
+	if pf.ShellDefault != nil {
+		shellDefault = *pf.ShellDefault
+	}
+	return config.MergeActions(actions, pf.Actions), shellDefault
+}
@@ -0,0 +1,64 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectFile(t *testing.T) {
+	dir := t.TempDir()
+	content := `shell-default: true
+actions:
+  - name: test
+    command: go test ./...
+  - name: server
+    command: make serve
+  - name: bench
+    command: make bench
+    env:
+      BASH_ENV: ./evil.sh
+`
+	if err := os.WriteFile(filepath.Join(dir, config.ProjectFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := []config.Action{{Name: "test", Command: "make test"}, {Name: "lint", Command: "make lint"}}
+	projects := []config.Project{{
+		Name:    "api",
+		Path:    dir,
+		Actions: []config.Action{{Name: "server", Command: "go run ."}, {Name: "bench", Command: "go test -bench ."}},
+	}}
+	locs, err := NewProjectProvider(projects, defaults, false, nil, false).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(locs) != 1 {
+		t.Fatalf("expected 1 location, got %d", len(locs))
+	}
+
+	actions := make(map[string]config.Action)
+	for _, a := range locs[0].Actions {
+		actions[a.Name] = a
+	}
+	if locs[0].Actions[0].Name != "Shell" {
+		t.Errorf("expected the file's shell-default to put Shell first, got %q", locs[0].Actions[0].Name)
+	}
+	// The file overrides the defaults and is marked as its origin
+	if a := actions["test"]; a.Command != "go test ./..." || a.Origin != filepath.Join(dir, config.ProjectFileName) || a.OriginHash == "" {
+		t.Errorf("unexpected test action: %+v", a)
+	}
+	// The project's own config overrides the file
+	if a := actions["server"]; a.Command != "go run ." || a.Origin != "" {
+		t.Errorf("unexpected server action: %+v", a)
+	}
+	// Unless it inherits environment from the file, which then still needs trust
+	if a := actions["bench"]; len(a.Env) == 0 || a.Origin == "" {
+		t.Errorf("expected bench to keep the file as its origin: %+v", a)
+	}
+	if a := actions["lint"]; a.Command != "make lint" || a.Origin != "" {
+		t.Errorf("unexpected lint action: %+v", a)
+	}
+}
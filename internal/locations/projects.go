@@ -2,6 +2,7 @@ package locations
 
 import (
 	"atelier-go/internal/config"
+	"atelier-go/internal/discover"
 	"atelier-go/internal/utils"
 	"context"
 	"os"
@@ -13,20 +14,26 @@ type ProjectProvider struct {
 	projects         []config.Project
 	defaultActions   []config.Action
 	rootShellDefault bool
+	discoverer       *discover.Discoverer
+	rootDiscover     bool
 }
 
 // NewProjectProvider creates a new ProjectProvider.
-func NewProjectProvider(projects []config.Project, defaultActions []config.Action, rootShellDefault bool) *ProjectProvider {
+// Projects with discover-actions enabled (rootDiscover unless they override it)
+// get the actions the discoverer finds in their directory.
+func NewProjectProvider(projects []config.Project, defaultActions []config.Action, rootShellDefault bool, discoverer *discover.Discoverer, rootDiscover bool) *ProjectProvider {
 	return &ProjectProvider{
 		projects:         projects,
 		defaultActions:   defaultActions,
 		rootShellDefault: rootShellDefault,
+		discoverer:       discoverer,
+		rootDiscover:     rootDiscover,
 	}
 }
 
 // Name returns the provider name.
 func (p *ProjectProvider) Name() string {
-	return "Project"
+	return SourceProject
 }
 
 // Fetch returns the list of configured projects as Locations.
@@ -50,21 +57,45 @@ func (p *ProjectProvider) Fetch(ctx context.Context) ([]Location, error) {
 			continue
 		}
 
-		actions := proj.Actions
-		if proj.UseDefaultActions() {
-			actions = config.MergeActions(p.defaultActions, proj.Actions)
+		dir := filepath.Clean(expandedPath)
+		actionsAt := func(dir string) []config.Action {
+			return p.actions(proj, dir)
 		}
 
-		shellDefault := proj.GetShellDefault(p.rootShellDefault)
-		actions = BuildActionsWithShell(actions, shellDefault)
-
 		locations = append(locations, Location{
-			Name:    proj.Name,
-			Path:    filepath.Clean(expandedPath),
-			Source:  p.Name(),
-			Actions: actions,
+			Name:           proj.Name,
+			Path:           dir,
+			Source:         p.Name(),
+			Actions:        actionsAt(dir),
+			SessionBackend: proj.SessionBackend,
+			Hooks:          proj.Hooks,
+			Layouts:        proj.Layouts,
+			Env:            proj.Env,
+			EnvFiles:       proj.EnvFiles,
+			actionsAt:      actionsAt,
 		})
 	}
 
+	if p.discoverer != nil {
+		// The cache only speeds up later runs, so failing to save it is not fatal
+		_ = p.discoverer.Save()
+	}
 	return locations, nil
 }
+
+// actions builds a project's actions for dir. The project's own config
+// overrides the .atelier.yaml in dir, which overrides the default actions.
+func (p *ProjectProvider) actions(proj config.Project, dir string) []config.Action {
+	var actions []config.Action
+	if proj.UseDefaultActions() {
+		actions = p.defaultActions
+	}
+	actions, shellDefault := applyProjectFile(dir, actions, p.rootShellDefault)
+	actions = config.MergeActions(actions, proj.Actions)
+	shellDefault = proj.GetShellDefault(shellDefault)
+
+	if p.discoverer != nil && proj.GetDiscoverActions(p.rootDiscover) {
+		actions = AppendDiscovered(actions, p.discoverer.Actions(dir))
+	}
+	return BuildActionsWithShell(FilterActions(actions, dir), shellDefault)
+}
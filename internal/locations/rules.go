@@ -0,0 +1,103 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/utils"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// rule is a compiled config.Rule.
+type rule struct {
+	match        string
+	regex        *regexp.Regexp
+	name         *template.Template
+	actions      []config.Action
+	shellDefault *bool
+}
+
+// RuleData is the data available to a rule's name template.
+type RuleData struct {
+	// Path is the directory's full path.
+	Path string
+	// Dir is the directory's base name.
+	Dir string
+	// Parent is the base name of the parent directory.
+	Parent string
+	// Groups holds the regex submatches; Groups 0 is the whole path.
+	Groups []string
+}
+
+// compileRules prepares rules for matching, expanding "~" in globs.
+func compileRules(rules []config.Rule) ([]rule, error) {
+	compiled := make([]rule, 0, len(rules))
+	for i, r := range rules {
+		c := rule{actions: r.Actions, shellDefault: r.ShellDefault}
+		if r.Regex != "" {
+			re, err := regexp.Compile("^(?:" + r.Regex + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d: invalid regex: %w", i, err)
+			}
+			c.regex = re
+		} else {
+			match, err := utils.ExpandPath(r.Match)
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d: %w", i, err)
+			}
+			c.match = filepath.ToSlash(match)
+		}
+		if r.Name != "" {
+			tmpl, err := template.New("rule-name").Option("missingkey=zero").Parse(r.Name)
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d: invalid name template: %w", i, err)
+			}
+			c.name = tmpl
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// groups returns the rule's submatches for a path, or nil if it does not match.
+func (r rule) groups(path string) []string {
+	if r.regex != nil {
+		return r.regex.FindStringSubmatch(path)
+	}
+	if ok, _ := utils.MatchGlob(r.match, filepath.ToSlash(path)); ok {
+		return []string{path}
+	}
+	return nil
+}
+
+// applyRules customizes a zoxide location with every rule matching its path,
+// in order. Actions accumulate, with later rules overriding actions of the
+// same name; the last name and shell-default set win.
+func applyRules(rules []rule, loc *Location, actions []config.Action, shellDefault bool) ([]config.Action, bool) {
+	for _, r := range rules {
+		groups := r.groups(loc.Path)
+		if groups == nil {
+			continue
+		}
+		actions = config.MergeActions(actions, r.actions)
+		if r.shellDefault != nil {
+			shellDefault = *r.shellDefault
+		}
+		if r.name != nil {
+			data := RuleData{
+				Path:   loc.Path,
+				Dir:    filepath.Base(loc.Path),
+				Parent: filepath.Base(filepath.Dir(loc.Path)),
+				Groups: groups,
+			}
+			var buf bytes.Buffer
+			if err := r.name.Execute(&buf, data); err == nil && strings.TrimSpace(buf.String()) != "" {
+				loc.Name = strings.TrimSpace(buf.String())
+			}
+		}
+	}
+	return actions, shellDefault
+}
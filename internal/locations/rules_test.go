@@ -0,0 +1,47 @@
+package locations
+
+import (
+	"testing"
+
+	"atelier-go/internal/config"
+)
+
+func TestApplyRules(t *testing.T) {
+	yes := true
+	rules, err := compileRules([]config.Rule{
+		{Match: "/work/**/services/*", Name: "{{.Parent}}/{{.Dir}}", Actions: []config.Action{
+			{Name: "Run", Command: "make run"},
+		}},
+		{Regex: `/work/([^/]+)/services/api`, Name: "{{index .Groups 1}} api", ShellDefault: &yes, Actions: []config.Action{
+			{Name: "Run", Command: "make run-api"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("compileRules failed: %v", err)
+	}
+	defaults := []config.Action{{Name: "Build", Command: "make"}}
+
+	loc := Location{Name: "api", Path: "/work/acme/services/api"}
+	actions, shellDefault := applyRules(rules, &loc, defaults, false)
+	if loc.Name != "acme api" {
+		t.Errorf("Name = %q, want the last matching rule's name", loc.Name)
+	}
+	if !shellDefault {
+		t.Error("expected shell-default from the second rule")
+	}
+	if len(actions) != 2 || actions[1].Command != "make run-api" {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+
+	loc = Location{Name: "web", Path: "/work/acme/services/web"}
+	actions, shellDefault = applyRules(rules, &loc, defaults, false)
+	if loc.Name != "services/web" || shellDefault || len(actions) != 2 || actions[1].Command != "make run" {
+		t.Errorf("unexpected result for web: %q %v %+v", loc.Name, shellDefault, actions)
+	}
+
+	loc = Location{Name: "notes", Path: "/home/notes"}
+	actions, _ = applyRules(rules, &loc, defaults, false)
+	if loc.Name != "notes" || len(actions) != 1 {
+		t.Errorf("expected non-matching directory to be unchanged, got %q %+v", loc.Name, actions)
+	}
+}
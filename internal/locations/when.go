@@ -0,0 +1,61 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// FilterActions drops the actions whose when conditions do not match the
+// directory. The input slice is not modified.
+func FilterActions(actions []config.Action, dir string) []config.Action {
+	filtered := make([]config.Action, 0, len(actions))
+	for _, a := range actions {
+		if a.When == nil || matchWhen(*a.When, dir) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// matchWhen reports whether every condition that is set matches.
+func matchWhen(w config.When, dir string) bool {
+	if len(w.OS) > 0 && !slices.Contains(w.OS, runtime.GOOS) {
+		return false
+	}
+	if len(w.Host) > 0 {
+		host, _ := os.Hostname()
+		short := strings.SplitN(host, ".", 2)[0]
+		if !slices.ContainsFunc(w.Host, func(p string) bool {
+			full, _ := path.Match(p, host)
+			base, _ := path.Match(p, short)
+			return full || base
+		}) {
+			return false
+		}
+	}
+	if len(w.Env) > 0 && !slices.ContainsFunc(w.Env, envMatches) {
+		return false
+	}
+	if len(w.FileExists) > 0 && !slices.ContainsFunc(w.FileExists, func(f string) bool {
+		_, err := os.Stat(filepath.Join(dir, f))
+		return err == nil
+	}) {
+		return false
+	}
+	return true
+}
+
+// envMatches checks a "VAR" or "VAR=value" condition.
+func envMatches(cond string) bool {
+	name, want, hasValue := strings.Cut(cond, "=")
+	value, ok := os.LookupEnv(name)
+	if hasValue {
+		return ok && value == want
+	}
+	return ok && value != ""
+}
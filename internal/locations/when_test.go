@@ -0,0 +1,44 @@
+package locations
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"atelier-go/internal/config"
+)
+
+func TestFilterActions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ATELIER_TEST_MODE", "ci")
+
+	actions := []config.Action{
+		{Name: "always"},
+		{Name: "npm", When: &config.When{FileExists: []string{"package.json"}}},
+		{Name: "go", When: &config.When{FileExists: []string{"package.json", "go.mod"}}},
+		{Name: "this-os", When: &config.When{OS: []string{runtime.GOOS}}},
+		{Name: "other-os", When: &config.When{OS: []string{"plan9-nope"}}},
+		{Name: "any-host", When: &config.When{Host: []string{"*"}}},
+		{Name: "env-set", When: &config.When{Env: []string{"ATELIER_TEST_MODE"}}},
+		{Name: "env-value", When: &config.When{Env: []string{"ATELIER_TEST_MODE=local"}}},
+		{Name: "all-of", When: &config.When{FileExists: []string{"go.mod"}, Env: []string{"ATELIER_TEST_UNSET"}}},
+	}
+
+	var got []string
+	for _, a := range FilterActions(actions, dir) {
+		got = append(got, a.Name)
+	}
+	want := []string{"always", "go", "this-os", "any-host", "env-set"}
+	if len(got) != len(want) {
+		t.Fatalf("FilterActions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("FilterActions = %v, want %v", got, want)
+		}
+	}
+}
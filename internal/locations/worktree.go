@@ -0,0 +1,180 @@
+package locations
+
+import (
+	"atelier-go/internal/utils"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxGitProcs limits how many git processes list worktrees at once.
+const maxGitProcs = 8
+
+// Worktree is a checkout listed by 'git worktree list'.
+type Worktree struct {
+	Path   string
+	Branch string
+	Bare   bool
+}
+
+// WorktreeProvider wraps a Provider, listing the worktrees of every git
+// repository it returns as child locations right after the repository.
+type WorktreeProvider struct {
+	Provider
+}
+
+// NewWorktreeProvider wraps a provider to add worktree sub-locations.
+func NewWorktreeProvider(p Provider) *WorktreeProvider {
+	return &WorktreeProvider{Provider: p}
+}
+
+// Fetch returns the wrapped provider's locations with their worktrees.
+func (w *WorktreeProvider) Fetch(ctx context.Context) ([]Location, error) {
+	locs, err := w.Provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([][]Location, len(locs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxGitProcs)
+	for i := range locs {
+		if _, err := os.Stat(filepath.Join(locs[i].Path, ".git")); err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			trees, err := ListWorktrees(ctx, locs[i].Path)
+			if err != nil {
+				return
+			}
+			locs[i].Repo = trees[0].Path
+			if !samePath(trees[0].Path, locs[i].Path) {
+				// Only the main worktree lists the others
+				return
+			}
+			for _, t := range trees[1:] {
+				if t.Bare {
+					continue
+				}
+				children[i] = append(children[i], worktreeLocation(locs[i], t))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	expanded := make([]Location, 0, len(locs))
+	for i, loc := range locs {
+		expanded = append(expanded, loc)
+		expanded = append(expanded, children[i]...)
+	}
+	return expanded, nil
+}
+
+// ListWorktrees returns the worktrees of the repository containing dir,
+// starting with the main worktree.
+func ListWorktrees(ctx context.Context, dir string) ([]Worktree, error) {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees of %s: %w", dir, err)
+	}
+	trees := parseWorktrees(out)
+	if len(trees) == 0 {
+		return nil, fmt.Errorf("no worktrees listed for %s", dir)
+	}
+	return trees, nil
+}
+
+// parseWorktrees parses 'git worktree list --porcelain' output, where each
+// worktree is a block of "key value" lines separated by a blank line.
+func parseWorktrees(out []byte) []Worktree {
+	var trees []Worktree
+	var cur *Worktree
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		switch key {
+		case "worktree":
+			trees = append(trees, Worktree{Path: filepath.Clean(value)})
+			cur = &trees[len(trees)-1]
+		case "branch":
+			if cur != nil {
+				cur.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "bare":
+			if cur != nil {
+				cur.Bare = true
+			}
+		}
+	}
+	return trees
+}
+
+// worktreeLocation builds the child location for a worktree. Settings that do
+// not depend on the directory, such as hooks, env and layouts, come from the
+// parent; actions are built from the worktree's own .atelier.yaml and tasks.
+func worktreeLocation(parent Location, t Worktree) Location {
+	label := t.Branch
+	if label == "" {
+		label = filepath.Base(t.Path)
+	}
+	child := parent
+	child.Name = parent.Name + "/" + label
+	child.Path = t.Path
+	child.Parent = parent.Path
+	if parent.actionsAt != nil {
+		child.Actions = parent.actionsAt(t.Path)
+	}
+	return child
+}
+
+// WorktreePath returns where a new worktree for branch is created: next to
+// the main worktree, named after the repository and the branch.
+func WorktreePath(repo, branch string) string {
+	return filepath.Join(filepath.Dir(repo), filepath.Base(repo)+"-"+utils.Sanitize(branch))
+}
+
+// CreateWorktree adds a worktree for branch to the repository of parent, a
+// location that is not itself a worktree child, creating the branch if it
+// does not exist. It returns the new worktree's location.
+func CreateWorktree(ctx context.Context, parent Location, branch string) (Location, error) {
+	repo := parent.Repo
+	if repo == "" {
+		repo = parent.Path
+	}
+	path := WorktreePath(repo, branch)
+
+	args := []string{"-C", repo, "worktree", "add", path, branch}
+	if exec.CommandContext(ctx, "git", "-C", repo, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch).Run() != nil {
+		args = []string{"-C", repo, "worktree", "add", "-b", branch, path}
+	}
+	if out, err := exec.CommandContext(ctx, "git", args...).CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return Location{}, fmt.Errorf("failed to create worktree: %s: %w", msg, err)
+		}
+		return Location{}, fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	child := worktreeLocation(parent, Worktree{Path: path, Branch: branch})
+	child.Repo = repo
+	return child, nil
+}
+
+// samePath reports whether two paths refer to the same directory.
+func samePath(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ca, errA := filepath.EvalSymlinks(a)
+	cb, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && ca == cb
+}
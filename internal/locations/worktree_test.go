@@ -0,0 +1,123 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := []byte(`worktree /src/app
+HEAD 1234
+branch refs/heads/main
+
+worktree /src/app-feature-x
+HEAD 5678
+branch refs/heads/feature/x
+
+worktree /src/app-detached
+HEAD 9abc
+detached
+
+`)
+	want := []Worktree{
+		{Path: "/src/app", Branch: "main"},
+		{Path: "/src/app-feature-x", Branch: "feature/x"},
+		{Path: "/src/app-detached"},
+	}
+	if got := parseWorktrees(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorktrees = %+v, want %+v", got, want)
+	}
+}
+
+func TestWorktreeProvider(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	repo := filepath.Join(base, "app")
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	git("commit", "-q", "--allow-empty", "-m", "init")
+
+	parent := Location{Name: "App", Path: repo, Source: "Project"}
+	created, err := CreateWorktree(context.Background(), parent, "feature/x")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	if created.Path != filepath.Join(base, "app-feature-x") || created.Name != "App/feature/x" || created.Parent != repo {
+		t.Errorf("unexpected worktree location: %+v", created)
+	}
+
+	p := NewWorktreeProvider(staticProvider{"Project", []Location{parent, {Name: "notes", Path: base}}})
+	locs, err := p.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if len(locs) != 3 {
+		t.Fatalf("expected the repository, its worktree and the plain directory, got %+v", locs)
+	}
+	if locs[0].Repo == "" || locs[1].Name != "App/feature/x" || locs[1].Parent != repo || locs[2].Name != "notes" {
+		t.Errorf("unexpected locations: %+v", locs)
+	}
+}
+
+func TestWorktreeOwnProjectFile(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	repo := filepath.Join(base, "app")
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	commit := exec.Command("git", "-C", repo, "commit", "-q", "--allow-empty", "-m", "init")
+	commit.Env = append(commit.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	if out, err := commit.CombinedOutput(); err != nil {
+		t.Fatalf("git commit failed: %v\n%s", err, out)
+	}
+
+	projects := NewProjectProvider([]config.Project{{Name: "App", Path: repo, Hooks: config.Hooks{OnCreate: "make deps"}}}, nil, false, nil, false)
+	parent, err := projects.Fetch(context.Background())
+	if err != nil || len(parent) != 1 {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	created, err := CreateWorktree(context.Background(), parent[0], "feature")
+	if err != nil {
+		t.Fatalf("CreateWorktree failed: %v", err)
+	}
+	file := "actions:\n  - name: bench\n    command: make bench\n"
+	if err := os.WriteFile(filepath.Join(created.Path, config.ProjectFileName), []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	locs, err := NewWorktreeProvider(projects).Fetch(context.Background())
+	if err != nil || len(locs) != 2 {
+		t.Fatalf("expected the repository and its worktree, got %+v (%v)", locs, err)
+	}
+	hasBench := func(loc Location) bool {
+		return slices.ContainsFunc(loc.Actions, func(a config.Action) bool {
+			return a.Name == "bench" && a.Origin == filepath.Join(created.Path, config.ProjectFileName)
+		})
+	}
+	if hasBench(locs[0]) || !hasBench(locs[1]) {
+		t.Errorf("expected only the worktree to have its own actions, got %+v", locs)
+	}
+	if locs[1].Hooks.OnCreate != "make deps" {
+		t.Errorf("expected the worktree to keep the project's hooks, got %+v", locs[1].Hooks)
+	}
+}
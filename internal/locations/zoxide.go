@@ -2,6 +2,7 @@ package locations
 
 import (
 	"atelier-go/internal/config"
+	"atelier-go/internal/discover"
 	"atelier-go/internal/utils"
 	"bufio"
 	"bytes"
@@ -9,21 +10,47 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 // ZoxideProvider implements Provider for zoxide directories.
 type ZoxideProvider struct {
+	limit          int
+	minScore       float64
+	exclude        []string
 	defaultActions []config.Action
 	shellDefault   bool
+	rules          []rule
+	discoverer     *discover.Discoverer
 }
 
 // NewZoxideProvider creates a new ZoxideProvider.
-func NewZoxideProvider(defaultActions []config.Action, shellDefault bool) *ZoxideProvider {
+// The settings' limit, min-score and exclude select which directories are listed.
+// Rules add actions, names and shell-default to directories matching their paths.
+// A non-nil discoverer adds the actions found in each directory.
+func NewZoxideProvider(settings config.Provider, defaultActions []config.Action, shellDefault bool, rules []config.Rule, discoverer *discover.Discoverer) (*ZoxideProvider, error) {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	exclude := make([]string, 0, len(settings.Exclude))
+	for _, e := range settings.Exclude {
+		expanded, err := utils.ExpandPath(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zoxide exclude %q: %w", e, err)
+		}
+		exclude = append(exclude, filepath.ToSlash(expanded))
+	}
 	return &ZoxideProvider{
+		limit:          settings.Limit,
+		minScore:       settings.MinScore,
+		exclude:        exclude,
 		defaultActions: defaultActions,
 		shellDefault:   shellDefault,
-	}
+		rules:          compiled,
+		discoverer:     discoverer,
+	}, nil
 }
 
 // Name returns the provider name.
@@ -33,35 +60,103 @@ func (z *ZoxideProvider) Name() string {
 
 // Fetch queries zoxide for frequent directories.
 func (z *ZoxideProvider) Fetch(ctx context.Context) ([]Location, error) {
-	cmd := exec.CommandContext(ctx, "zoxide", "query", "-l")
+	cmd := exec.CommandContext(ctx, "zoxide", "query", "--list", "--score")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run zoxide query: %w", err)
 	}
+	entries, err := parseZoxide(output)
+	if err != nil {
+		return nil, err
+	}
 
 	var locations []Location
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-	for scanner.Scan() {
-		path := strings.TrimSpace(scanner.Text())
-		if path != "" {
-			cleanPath := filepath.Clean(path)
-			// Canonicalize path (fixes case sensitivity on macOS)
-			if canonical, err := utils.GetCanonicalPath(cleanPath); err == nil {
-				cleanPath = canonical
-			}
+	for _, e := range entries {
+		if z.limit > 0 && len(locations) >= z.limit {
+			break
+		}
+		if e.score < z.minScore || z.excluded(e.path) {
+			continue
+		}
 
-			locations = append(locations, Location{
-				Name:    filepath.Base(cleanPath),
-				Path:    cleanPath,
-				Source:  z.Name(),
-				Actions: BuildActionsWithShell(z.defaultActions, z.shellDefault),
-			})
+		cleanPath := filepath.Clean(e.path)
+		// Canonicalize path (fixes case sensitivity on macOS)
+		if canonical, err := utils.GetCanonicalPath(cleanPath); err == nil {
+			cleanPath = canonical
 		}
+
+		loc := Location{
+			Name:      filepath.Base(cleanPath),
+			Path:      cleanPath,
+			Source:    z.Name(),
+			actionsAt: z.actions,
+		}
+		loc.Actions = z.build(&loc)
+		locations = append(locations, loc)
 	}
 
+	if z.discoverer != nil {
+		// The cache only speeds up later runs, so failing to save it is not fatal
+		_ = z.discoverer.Save()
+	}
+
+	return locations, nil
+}
+
+// zoxideEntry is a directory listed by zoxide with its frecency score.
+type zoxideEntry struct {
+	score float64
+	path  string
+}
+
+// parseZoxide reads the "score path" lines of "zoxide query --list --score",
+// which come sorted from the highest score down.
+func parseZoxide(output []byte) ([]zoxideEntry, error) {
+	var entries []zoxideEntry
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		scoreStr, path, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("failed to parse zoxide output line %q", line)
+		}
+		score, err := strconv.ParseFloat(scoreStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse zoxide score %q: %w", scoreStr, err)
+		}
+		entries = append(entries, zoxideEntry{score: score, path: strings.TrimSpace(path)})
+	}
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("failed to parse zoxide output: %w", err)
 	}
+	return entries, nil
+}
 
-	return locations, nil
+// excluded reports whether a directory matches one of the exclude globs.
+func (z *ZoxideProvider) excluded(path string) bool {
+	for _, pattern := range z.exclude {
+		if ok, _ := utils.MatchGlob(pattern, filepath.ToSlash(path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// build returns the actions of a location from the .atelier.yaml and tasks
+// in its directory, applying the rules matching it, which may also rename it.
+func (z *ZoxideProvider) build(loc *Location) []config.Action {
+	actions, shellDefault := applyProjectFile(loc.Path, z.defaultActions, z.shellDefault)
+	actions, shellDefault = applyRules(z.rules, loc, actions, shellDefault)
+	if z.discoverer != nil {
+		actions = AppendDiscovered(actions, z.discoverer.Actions(loc.Path))
+	}
+	return BuildActionsWithShell(FilterActions(actions, loc.Path), shellDefault)
+}
+
+// actions builds the actions for dir, keeping the location's name as it is.
+func (z *ZoxideProvider) actions(dir string) []config.Action {
+	return z.build(&Location{Name: filepath.Base(dir), Path: dir})
 }
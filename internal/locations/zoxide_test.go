@@ -0,0 +1,47 @@
+package locations
+
+import (
+	"atelier-go/internal/config"
+	"testing"
+)
+
+func TestParseZoxide(t *testing.T) {
+	output := []byte("  48.0 /home/user/api\n   2.5 /home/user/my docs\n\n   0.3 /tmp/scratch\n")
+	entries, err := parseZoxide(output)
+	if err != nil {
+		t.Fatalf("parseZoxide failed: %v", err)
+	}
+	want := []zoxideEntry{{48, "/home/user/api"}, {2.5, "/home/user/my docs"}, {0.3, "/tmp/scratch"}}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], entries[i])
+		}
+	}
+
+	if _, err := parseZoxide([]byte("high /home/user\n")); err == nil {
+		t.Error("expected an invalid score to fail")
+	}
+}
+
+func TestZoxideExclude(t *testing.T) {
+	z, err := NewZoxideProvider(config.Provider{Name: "zoxide", Exclude: []string{"/tmp/**", "/home/*/Downloads"}}, nil, false, nil, nil)
+	if err != nil {
+		t.Fatalf("NewZoxideProvider failed: %v", err)
+	}
+
+	tests := map[string]bool{
+		"/tmp/scratch":             true,
+		"/tmp/a/b":                 true,
+		"/home/user/Downloads":     true,
+		"/home/user/Downloads/zip": false,
+		"/home/user/api":           false,
+	}
+	for path, want := range tests {
+		if got := z.excluded(path); got != want {
+			t.Errorf("excluded(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Package output renders command results in human and machine-readable formats.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// SchemaVersion is bumped whenever a field is renamed or removed from
+// structured output. Adding fields does not change the version.
+const SchemaVersion = 1
+
+// Supported output formats.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatTSV   = "tsv"
+)
+
+// Options selects how results are printed.
+type Options struct {
+	// Format is one of table, json, yaml or tsv.
+	Format string
+	// Template is a Go template executed once per item. It takes precedence over Format.
+	Template string
+}
+
+// Validate checks that the options name a known format and a parseable template.
+func (o Options) Validate() error {
+	if o.Template != "" {
+		if _, err := template.New("format").Parse(o.Template); err != nil {
+			return fmt.Errorf("invalid --format template: %w", err)
+		}
+		return nil
+	}
+	switch o.Format {
+	case "", FormatTable, FormatJSON, FormatYAML, FormatTSV:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (expected table, json, yaml or tsv)", o.Format)
+	}
+}
+
+// Renderer describes how a list of items is presented in each format.
+type Renderer[T any] struct {
+	// Kind names the items in structured output, e.g. "locations".
+	Kind string
+	// Table prints the default human-readable output.
+	Table func(w io.Writer, items []T) error
+	// Headers and Row define the tsv columns.
+	Headers []string
+	Row     func(item T) []string
+}
+
+// document is the envelope written for json and yaml output.
+type document[T any] struct {
+	SchemaVersion int    `json:"schema_version" yaml:"schema_version"`
+	Kind          string `json:"kind" yaml:"kind"`
+	Items         []T    `json:"items" yaml:"items"`
+}
+
+// Write prints items to w according to opts.
+func Write[T any](w io.Writer, opts Options, r Renderer[T], items []T) error {
+	if opts.Template != "" {
+		return writeTemplate(w, opts.Template, items)
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+	doc := document[T]{SchemaVersion: SchemaVersion, Kind: r.Kind, Items: items}
+
+	switch opts.Format {
+	case "", FormatTable:
+		return r.Table(w, items)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("error writing json: %w", err)
+		}
+		return nil
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("error writing yaml: %w", err)
+		}
+		return enc.Close()
+	case FormatTSV:
+		return writeTSV(w, r, items)
+	default:
+		return fmt.Errorf("unknown output format %q", opts.Format)
+	}
+}
+
+func writeTemplate[T any](w io.Writer, text string, items []T) error {
+	tmpl, err := template.New("format").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("error executing template: %w", err)
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return fmt.Errorf("error writing output: %w", err)
+		}
+	}
+	return nil
+}
+
+func writeTSV[T any](w io.Writer, r Renderer[T], items []T) error {
+	if _, err := fmt.Fprintln(w, strings.Join(r.Headers, "\t")); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+	for _, item := range items {
+		row := r.Row(item)
+		for i, field := range row {
+			row[i] = tsvEscape(field)
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return fmt.Errorf("error writing row: %w", err)
+		}
+	}
+	return nil
+}
+
+// tsvEscape keeps each field on a single line and column.
+func tsvEscape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r").Replace(s)
+}
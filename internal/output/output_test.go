@@ -0,0 +1,111 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	Name string `json:"name" yaml:"name"`
+	Note string `json:"note" yaml:"note"`
+}
+
+var testRenderer = Renderer[item]{
+	Kind: "items",
+	Table: func(w io.Writer, items []item) error {
+		_, err := io.WriteString(w, "table\n")
+		return err
+	},
+	Headers: []string{"NAME", "NOTE"},
+	Row:     func(i item) []string { return []string{i.Name, i.Note} },
+}
+
+func TestWrite(t *testing.T) {
+	items := []item{{Name: "a", Note: "tab\there"}, {Name: "b", Note: "line\nbreak"}}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		items    []item
+		expected string
+	}{
+		{name: "table", opts: Options{Format: FormatTable}, items: items, expected: "table\n"},
+		{name: "default", opts: Options{}, items: items, expected: "table\n"},
+		{
+			name:     "tsv escapes fields",
+			opts:     Options{Format: FormatTSV},
+			items:    items,
+			expected: "NAME\tNOTE\na\ttab\\there\nb\tline\\nbreak\n",
+		},
+		{
+			name:     "template",
+			opts:     Options{Format: FormatJSON, Template: "{{.Name}}!"},
+			items:    items,
+			expected: "a!\nb!\n",
+		},
+		{
+			name:     "yaml",
+			opts:     Options{Format: FormatYAML},
+			items:    items[:1],
+			expected: "schema_version: 1\nkind: items\nitems:\n  - name: a\n    note: \"tab\\there\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.opts, testRenderer, tt.items); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWrite_JSONEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Options{Format: FormatJSON}, testRenderer, nil); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc["schema_version"] != float64(SchemaVersion) {
+		t.Errorf("expected schema_version %d, got %v", SchemaVersion, doc["schema_version"])
+	}
+	if doc["kind"] != "items" {
+		t.Errorf("expected kind items, got %v", doc["kind"])
+	}
+	// Empty results must encode as an empty list, not null
+	if !strings.Contains(buf.String(), `"items": []`) {
+		t.Errorf("expected empty items list, got %s", buf.String())
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "json", opts: Options{Format: "json"}},
+		{name: "unknown", opts: Options{Format: "xml"}, wantErr: true},
+		{name: "template", opts: Options{Template: "{{.Name}}"}},
+		{name: "bad template", opts: Options{Template: "{{.Name"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package sessions
+
+import (
+	"atelier-go/internal/env"
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// AbducoBackend implements Backend using abduco.
+type AbducoBackend struct{}
+
+// Name returns the backend name.
+func (b *AbducoBackend) Name() string {
+	return "abduco"
+}
+
+// Attach connects to an existing abduco session or creates a new one with the given name.
+func (b *AbducoBackend) Attach(name string, dir string, command []string, vars []string) error {
+	args := []string{"-a", name}
+	if len(command) > 0 {
+		args = append([]string{"-A", name}, command...)
+	}
+
+	cmd := newInteractiveCmd(dir, "abduco", args...)
+	cmd.Env = processEnv(vars)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("abduco session ended with error: %w", err)
+	}
+	return nil
+}
+
+// Start creates a detached abduco session.
+func (b *AbducoBackend) Start(name string, dir string, command []string, vars []string) error {
+	if len(command) == 0 {
+		command = []string{env.DetectShell()}
+	}
+	args := append([]string{"-n", name}, command...)
+	if err := runDetached(dir, vars, "abduco", args...); err != nil {
+		return fmt.Errorf("failed to start abduco session %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the active abduco sessions.
+func (b *AbducoBackend) List() ([]Session, error) {
+	output, err := exec.Command("abduco").Output()
+	if err != nil && len(output) == 0 {
+		return nil, fmt.Errorf("failed to list abduco sessions: %w", err)
+	}
+	return parseAbducoList(output)
+}
+
+// Kill is not supported because abduco has no command to terminate a session.
+func (b *AbducoBackend) Kill(name string) error {
+	return fmt.Errorf("cannot kill abduco session %s: %w", name, ErrUnsupported)
+}
+
+// parseAbducoList parses the session listing printed by 'abduco' without arguments.
+// After an "Active sessions" header, each line is "<status> <date>\t<time>\t<name>",
+// where a "*" status marks a session with a client connected.
+func parseAbducoList(output []byte) ([]Session, error) {
+	var sessions []Session
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "Active sessions") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		name := strings.TrimSpace(fields[len(fields)-1])
+		if name == "" {
+			continue
+		}
+		sessions = append(sessions, Session{ID: name, Backend: "abduco", Attached: strings.HasPrefix(line, "*")})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse session list: %w", err)
+	}
+
+	return sessions, nil
+}
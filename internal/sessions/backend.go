@@ -0,0 +1,114 @@
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"atelier-go/internal/config"
+)
+
+// DefaultBackend is the session backend used when none is configured.
+const DefaultBackend = config.DefaultSessionBackend
+
+// ErrUnsupported is returned when a backend cannot perform an operation.
+var ErrUnsupported = errors.New("operation not supported by session backend")
+
+// ErrRunning is returned when starting a session that already exists.
+var ErrRunning = errors.New("session already running")
+
+// Backend defines the interface for persistent session tools such as zmx or tmux.
+type Backend interface {
+	// Name returns the backend identifier used in configuration.
+	Name() string
+	// Attach connects to the named session, creating it in dir with command if needed.
+	// vars holds KEY=VALUE pairs to set in a newly created session; they must
+	// not be passed as command arguments.
+	Attach(name string, dir string, command []string, vars []string) error
+	// List returns the sessions currently managed by the backend.
+	List() ([]Session, error)
+	// Kill terminates the named session.
+	Kill(name string) error
+}
+
+// Renamer is implemented by backends that can rename a running session.
+type Renamer interface {
+	Rename(oldName, newName string) error
+}
+
+// Starter is implemented by backends that can create a session without attaching to it.
+// vars are handled as in Backend.Attach.
+type Starter interface {
+	Start(name string, dir string, command []string, vars []string) error
+}
+
+// Switcher is implemented by backends whose Attach can return before the user
+// detaches, when it switches an existing client to the session instead.
+type Switcher interface {
+	Switches() bool
+}
+
+// Sender is implemented by backends that can type input into a running session.
+// When enter is true the input is submitted as a command.
+type Sender interface {
+	Send(name string, text string, enter bool) error
+}
+
+// Capturer is implemented by backends that can return a session's recent output.
+type Capturer interface {
+	Capture(name string, lines int) (string, error)
+}
+
+// NewBackend returns the backend registered under the given name.
+// An empty name selects DefaultBackend.
+func NewBackend(name string) (Backend, error) {
+	switch strings.ToLower(name) {
+	case "", DefaultBackend:
+		return &ZmxBackend{}, nil
+	case "tmux":
+		return &TmuxBackend{}, nil
+	case "shpool":
+		return &ShpoolBackend{}, nil
+	case "abduco":
+		return &AbducoBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown session backend %q", name)
+	}
+}
+
+// lastLines returns at most n trailing lines of s, ignoring trailing blank lines.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n "), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newInteractiveCmd builds a command wired to the current terminal.
+func newInteractiveCmd(dir string, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// runDetached runs a backend command that returns once the session is created,
+// including its output in the error on failure. vars are added to the
+// command's environment.
+func runDetached(dir string, vars []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = processEnv(vars)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %w", msg, err)
+		}
+		return err
+	}
+	return nil
+}
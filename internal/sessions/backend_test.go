@@ -0,0 +1,158 @@
+package sessions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"atelier-go/internal/config"
+)
+
+func TestNewBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{name: "", expected: "zmx"},
+		{name: "zmx", expected: "zmx"},
+		{name: "TMUX", expected: "tmux"},
+		{name: "shpool", expected: "shpool"},
+		{name: "abduco", expected: "abduco"},
+		{name: "screen", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBackend(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBackend(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if err == nil && b.Name() != tt.expected {
+				t.Errorf("expected backend %s, got %s", tt.expected, b.Name())
+			}
+		})
+	}
+}
+
+func TestNewBackendKnowsConfiguredNames(t *testing.T) {
+	for _, name := range config.SessionBackends {
+		b, err := NewBackend(name)
+		if err != nil {
+			t.Errorf("NewBackend(%q) error = %v", name, err)
+			continue
+		}
+		if b.Name() != name {
+			t.Errorf("NewBackend(%q) returned backend %s", name, b.Name())
+		}
+	}
+}
+
+func TestParseLists(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func([]byte) ([]Session, error)
+		output   string
+		expected []Session
+	}{
+		{
+			name:   "zmx",
+			parse:  parseZmxList,
+			output: "session_name=my-app\t/home/user/my-app\nother\n",
+			expected: []Session{
+				{ID: "my-app", Path: "/home/user/my-app", Backend: "zmx"},
+				{ID: "other", Backend: "zmx"},
+			},
+		},
+		{
+			name:   "tmux",
+			parse:  parseTmuxList,
+			output: "my-app%3Arun-server\t/home/user/my-app\t1\nnotes\t/home/user/notes\t0\n",
+			expected: []Session{
+				{ID: "my-app:run-server", Path: "/home/user/my-app", Backend: "tmux", Attached: true},
+				{ID: "notes", Path: "/home/user/notes", Backend: "tmux"},
+			},
+		},
+		{
+			name:   "shpool",
+			parse:  parseShpoolList,
+			output: "NAME\tSTARTED_AT\tSTATUS\nmy-app\t2025-01-01T10:00:00Z\tattached\n",
+			expected: []Session{
+				{ID: "my-app", Backend: "shpool", Attached: true},
+			},
+		},
+		{
+			name:   "abduco",
+			parse:  parseAbducoList,
+			output: "Active sessions (on host box)\n* Fri\t 2025-01-01 10:00:00\tmy-app:editor\n",
+			expected: []Session{
+				{ID: "my-app:editor", Backend: "abduco", Attached: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.output))
+			if err != nil {
+				t.Fatalf("parse failed: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d sessions, got %d: %v", len(tt.expected), len(got), got)
+			}
+			for i, s := range tt.expected {
+				if got[i] != s {
+					t.Errorf("at index %d: expected %+v, got %+v", i, s, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAbducoKillUnsupported(t *testing.T) {
+	err := (&AbducoBackend{}).Kill("my-app")
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestBackendCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		starter bool
+		sender  bool
+	}{
+		{"zmx", true, true},
+		{"tmux", true, true},
+		{"shpool", false, false},
+		{"abduco", true, false},
+	}
+	for _, tt := range tests {
+		b, err := NewBackend(tt.name)
+		if err != nil {
+			t.Fatalf("NewBackend(%s) failed: %v", tt.name, err)
+		}
+		if _, ok := b.(Starter); ok != tt.starter {
+			t.Errorf("%s: expected Starter=%v", tt.name, tt.starter)
+		}
+		if _, ok := b.(Sender); ok != tt.sender {
+			t.Errorf("%s: expected Sender=%v", tt.name, tt.sender)
+		}
+	}
+
+	if err := (&ZmxBackend{}).Send("my-app", "make test", false); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected zmx to reject input without enter, got %v", err)
+	}
+}
+
+func TestTmuxNameRoundTrip(t *testing.T) {
+	for _, name := range []string{"my-app:run-server", "my_app:shell", "host.local:my_app#2", "100%:done", "a%3Ab"} {
+		encoded := tmuxName(name)
+		if strings.ContainsAny(encoded, ":.") {
+			t.Errorf("tmuxName(%q) = %q still contains characters tmux rejects", name, encoded)
+		}
+		if got := fromTmuxName(encoded); got != name {
+			t.Errorf("fromTmuxName(tmuxName(%q)) = %q", name, got)
+		}
+	}
+}
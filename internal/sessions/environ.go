@@ -0,0 +1,95 @@
+package sessions
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/env"
+	"atelier-go/internal/utils"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// environment returns the variables to set in the target's session as
+// KEY=VALUE pairs. Env files are loaded first (global, project, then action),
+// followed by env maps in the same order; later values win.
+// Relative env file paths are resolved against the target's directory.
+func (m *Manager) environment(t Target) ([]string, error) {
+	var files []string
+	var global map[string]string
+	if m != nil {
+		files = append(files, m.envFiles...)
+		global = m.env
+	}
+	files = append(files, t.EnvFiles...)
+
+	vars := make(map[string]string)
+	for _, f := range files {
+		path, err := utils.ExpandPath(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand env file %s: %w", f, err)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(t.Path, path)
+		}
+		loaded, err := env.LoadDotenv(path)
+		if err != nil {
+			return nil, err
+		}
+		vars = config.MergeEnv(vars, loaded)
+	}
+	vars = config.MergeEnv(vars, config.MergeEnv(global, t.Env))
+
+	pairs := make([]string, 0, len(vars))
+	for k, v := range vars {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return pairs, nil
+}
+
+// processEnv returns the environment for a backend process that may create
+// a session, adding vars to the current one. It returns nil without vars so
+// the process simply inherits the current environment.
+func processEnv(vars []string) []string {
+	if len(vars) == 0 {
+		return nil
+	}
+	return append(os.Environ(), vars...)
+}
+
+// sourceEnv writes vars to a private temporary file and wraps command so the
+// session loads and removes the file before running it. This keeps values off
+// the command line for backends whose sessions do not inherit the client's
+// environment. Without a command, the user's shell is started. The returned
+// path is empty when there is nothing to load.
+func sourceEnv(vars []string, command []string) ([]string, string, error) {
+	if len(vars) == 0 {
+		return command, "", nil
+	}
+	if len(command) == 0 {
+		command = []string{env.DetectShell()}
+	}
+
+	f, err := os.CreateTemp("", "atelier-env-*")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create env file: %w", err)
+	}
+	var b strings.Builder
+	for _, v := range vars {
+		b.WriteString(utils.ShellJoin([]string{"export", v}) + "\n")
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, "", fmt.Errorf("failed to write env file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return nil, "", fmt.Errorf("failed to write env file: %w", err)
+	}
+
+	wrapped := []string{"sh", "-c", `. "$0"; rm -f "$0"; exec "$@"`, f.Name()}
+	return append(wrapped, command...), f.Name(), nil
+}
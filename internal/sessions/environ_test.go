@@ -0,0 +1,74 @@
+package sessions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("A=file\nB=file\nC=file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{env: map[string]string{"A": "global", "G": "global"}}
+	target := Target{
+		Path:     dir,
+		Env:      map[string]string{"B": "project"},
+		EnvFiles: []string{".env"},
+	}
+
+	vars, err := m.environment(target)
+	if err != nil {
+		t.Fatalf("environment failed: %v", err)
+	}
+	want := []string{"A=global", "B=project", "C=file", "G=global"}
+	if !reflect.DeepEqual(vars, want) {
+		t.Errorf("environment = %v, want %v", vars, want)
+	}
+
+	target.EnvFiles = []string{"missing.env"}
+	if _, err := m.environment(target); err == nil {
+		t.Error("expected an error for a missing env file")
+	}
+}
+
+func TestSourceEnv(t *testing.T) {
+	vars := []string{"TOKEN=it's secret"}
+	command, file, err := sourceEnv(vars, []string{"npm", "run", "dev"})
+	if err != nil {
+		t.Fatalf("sourceEnv failed: %v", err)
+	}
+	defer os.Remove(file)
+
+	for _, arg := range command {
+		if strings.Contains(arg, "secret") {
+			t.Errorf("value leaked into command: %v", command)
+		}
+	}
+	if want := []string{file, "npm", "run", "dev"}; !reflect.DeepEqual(command[3:], want) {
+		t.Errorf("command = %v, want it to end with %v", command, want)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("env file mode = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "export 'TOKEN=it'\\''s secret'\n"; string(data) != want {
+		t.Errorf("env file = %q, want %q", data, want)
+	}
+
+	if got, file, _ := sourceEnv(nil, []string{"bash"}); !reflect.DeepEqual(got, []string{"bash"}) || file != "" {
+		t.Errorf("sourceEnv without vars = %v, %q", got, file)
+	}
+}
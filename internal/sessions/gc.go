@@ -0,0 +1,67 @@
+package sessions
+
+import (
+	"path"
+	"time"
+)
+
+// GCPolicy decides which sessions are idle long enough to be collected.
+type GCPolicy struct {
+	// MaxIdle is the longest a session may go without being attached.
+	MaxIdle time.Duration
+	// Keep holds session name globs or project names that are never collected.
+	Keep []string
+}
+
+// IdleSession is a session selected for collection.
+type IdleSession struct {
+	Session
+	// LastActive is the last time atelier-go attached to the session.
+	LastActive time.Time
+}
+
+// Select returns the sessions whose last attach is older than MaxIdle.
+// Sessions without recorded metadata are never selected, since their
+// activity is unknown, and neither are sessions with a client attached,
+// whether the backend reports it or atelier-go recorded it.
+func (p GCPolicy) Select(sessions []Session, now time.Time) []IdleSession {
+	var idle []IdleSession
+	for _, sess := range sessions {
+		md := sess.Metadata
+		if md == nil || sess.Attached || md.InUse() || p.keeps(sess) {
+			continue
+		}
+
+		last := md.LastAttachedAt
+		if last.IsZero() {
+			last = md.CreatedAt
+		}
+		if last.IsZero() || now.Sub(last) < p.MaxIdle {
+			continue
+		}
+		idle = append(idle, IdleSession{Session: sess, LastActive: last})
+	}
+	return idle
+}
+
+// keeps reports whether the session is protected by the keep list.
+func (p GCPolicy) keeps(sess Session) bool {
+	for _, k := range p.Keep {
+		if ok, _ := path.Match(k, sess.ID); ok {
+			return true
+		}
+		if sess.Metadata != nil && sess.Metadata.Project == k {
+			return true
+		}
+	}
+	return false
+}
+
+// IdleSessions lists the live sessions selected by the policy.
+func (m *Manager) IdleSessions(p GCPolicy) ([]IdleSession, error) {
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	return p.Select(sessions, time.Now()), nil
+}
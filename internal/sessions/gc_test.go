@@ -0,0 +1,89 @@
+package sessions
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestGCPolicySelect(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+
+	list := []Session{
+		{ID: "my-app:shell", Metadata: &Metadata{Project: "My App", LastAttachedAt: ago(100 * time.Hour)}},
+		{ID: "my-app:server", Metadata: &Metadata{Project: "My App", LastAttachedAt: ago(time.Hour)}},
+		{ID: "dotfiles:shell", Metadata: &Metadata{Project: "Dotfiles", LastAttachedAt: ago(500 * time.Hour)}},
+		{ID: "notes:shell", Metadata: &Metadata{Project: "Notes", LastAttachedAt: ago(200 * time.Hour)}},
+		{ID: "old:shell", Metadata: &Metadata{Project: "Old", CreatedAt: ago(80 * time.Hour)}},
+		{ID: "busy:shell", Attached: true, Metadata: &Metadata{Project: "Busy", LastAttachedAt: ago(300 * time.Hour)}},
+		// zmx does not report attached clients, so the recorded attach protects it
+		{ID: "long:shell", Metadata: &Metadata{Project: "Long", LastAttachedAt: ago(300 * time.Hour), AttachedBy: []int{os.Getpid()}}},
+		{ID: "untracked"},
+	}
+
+	policy := GCPolicy{MaxIdle: 72 * time.Hour, Keep: []string{"dotfiles:*", "Notes"}}
+	idle := policy.Select(list, now)
+
+	var got []string
+	for _, s := range idle {
+		got = append(got, s.ID)
+	}
+	want := []string{"my-app:shell", "old:shell"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+	if !idle[1].LastActive.Equal(ago(80 * time.Hour)) {
+		t.Errorf("expected creation time fallback, got %v", idle[1].LastActive)
+	}
+}
+
+func TestMetadataAttachedBy(t *testing.T) {
+	// A client that exited without detaching does not keep the session in use
+	done := exec.Command("true")
+	if err := done.Run(); err != nil {
+		t.Skipf("cannot run true: %v", err)
+	}
+	md := Metadata{AttachedBy: []int{done.Process.Pid}}
+	if md.InUse() {
+		t.Error("expected an exited client to be ignored")
+	}
+
+	md.attach(os.Getpid())
+	if !md.InUse() || len(md.AttachedBy) != 1 {
+		t.Errorf("expected only this client to be recorded, got %v", md.AttachedBy)
+	}
+	md.detach(os.Getpid())
+	if md.InUse() || len(md.AttachedBy) != 0 {
+		t.Errorf("expected no clients after detaching, got %v", md.AttachedBy)
+	}
+}
+
+func TestIdleSessionsSkipsAttachedClient(t *testing.T) {
+	backend := &fakeBackend{sessions: []Session{{ID: "api", Backend: "fake"}}}
+	names, _ := newNamer("")
+	m := &Manager{backend: backend, backends: []Backend{backend}, store: NewMetadataStoreAt(t.TempDir()), names: names}
+	policy := GCPolicy{MaxIdle: time.Nanosecond}
+
+	if err := m.recordAttach(Target{Name: "api", Path: "/home/user/api"}, nil, "fake", false); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if idle, err := m.IdleSessions(policy); err != nil || len(idle) != 0 {
+		t.Errorf("expected a session being attached to be kept, got %v (%v)", idle, err)
+	}
+
+	if err := m.recordDetach("api"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if idle, err := m.IdleSessions(policy); err != nil || len(idle) != 1 {
+		t.Errorf("expected the session to be idle after detaching, got %v (%v)", idle, err)
+	}
+}
@@ -0,0 +1,84 @@
+package sessions
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/env"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// HookEvent names a point in a session's lifecycle.
+type HookEvent string
+
+// Lifecycle events, matching the keys of the hooks config section.
+const (
+	// HookOnCreate runs before a session that does not exist yet is created.
+	HookOnCreate HookEvent = "on-create"
+	// HookPreAttach runs before every attach.
+	HookPreAttach HookEvent = "pre-attach"
+	// HookPostDetach runs after every detach.
+	HookPostDetach HookEvent = "post-detach"
+	// HookOnExit runs after detaching when the session is no longer running.
+	HookOnExit HookEvent = "on-exit"
+)
+
+// hookCommand returns the command configured for an event.
+func hookCommand(h config.Hooks, event HookEvent) string {
+	switch event {
+	case HookOnCreate:
+		return h.OnCreate
+	case HookPreAttach:
+		return h.PreAttach
+	case HookPostDetach:
+		return h.PostDetach
+	case HookOnExit:
+		return h.OnExit
+	}
+	return ""
+}
+
+// runHooks runs the target's hooks for an event in order (project, then action).
+// Each hook runs in the target's directory with the session described in
+// ATELIER_* environment variables, plus the session's own variables.
+// The first failing hook stops the rest.
+// Interactive hooks share the terminal; otherwise their output is only
+// reported when they fail.
+func runHooks(t Target, event HookEvent, vars []string, interactive bool) error {
+	for _, h := range t.Hooks {
+		command := hookCommand(h, event)
+		if command == "" {
+			continue
+		}
+
+		cmd := exec.Command(env.DetectShell(), "-c", command)
+		cmd.Dir = t.Path
+		cmd.Env = append(os.Environ(),
+			"ATELIER_HOOK="+string(event),
+			"ATELIER_SESSION="+t.Name,
+			"ATELIER_PATH="+t.Path,
+			"ATELIER_PROJECT="+t.Project,
+			"ATELIER_ACTION="+t.Action,
+		)
+		cmd.Env = append(cmd.Env, vars...)
+
+		if interactive {
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("%s hook failed: %w", event, err)
+			}
+			continue
+		}
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if msg := strings.TrimSpace(string(out)); msg != "" {
+				return fmt.Errorf("%s hook failed: %s: %w", event, msg, err)
+			}
+			return fmt.Errorf("%s hook failed: %w", event, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package sessions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"atelier-go/internal/config"
+)
+
+func TestRunHooks(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	dir := t.TempDir()
+
+	target := Target{
+		Name:    "my-app:shell",
+		Path:    dir,
+		Project: "My App",
+		Action:  "shell",
+		Hooks: []config.Hooks{
+			{PreAttach: `echo "project $ATELIER_HOOK $ATELIER_SESSION $ATELIER_PROJECT" >> hooks.log`},
+			{PreAttach: `echo "action $ATELIER_ACTION $(pwd)" >> hooks.log`, OnExit: "exit 3"},
+		},
+	}
+
+	if err := runHooks(target, HookPreAttach, nil, true); err != nil {
+		t.Fatalf("runHooks failed: %v", err)
+	}
+	// Events without hooks are a no-op
+	if err := runHooks(target, HookOnCreate, nil, true); err != nil {
+		t.Fatalf("runHooks failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "hooks.log"))
+	if err != nil {
+		t.Fatalf("expected hooks to write a log: %v", err)
+	}
+	realDir, _ := filepath.EvalSymlinks(dir)
+	want := "project pre-attach my-app:shell My App\naction shell " + realDir + "\n"
+	if string(content) != want {
+		t.Errorf("unexpected hook output:\n%q\nwant\n%q", content, want)
+	}
+
+	target.Hooks[1].OnExit = "echo cleanup failed; exit 3"
+	err = runHooks(target, HookOnExit, nil, false)
+	if err == nil || !strings.Contains(err.Error(), "on-exit hook failed: cleanup failed") {
+		t.Errorf("expected on-exit failure, got %v", err)
+	}
+}
+
+// fakeBackend keeps sessions in memory.
+type fakeBackend struct {
+	sessions []Session
+}
+
+func (b *fakeBackend) Name() string { return "fake" }
+
+func (b *fakeBackend) Attach(name string, dir string, command []string, vars []string) error {
+	return nil
+}
+
+func (b *fakeBackend) List() ([]Session, error) { return b.sessions, nil }
+
+func (b *fakeBackend) Kill(name string) error {
+	for i, s := range b.sessions {
+		if s.ID == name {
+			b.sessions = append(b.sessions[:i], b.sessions[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("no such session")
+}
+
+func TestKillRunsExitHooks(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+	dir := t.TempDir()
+	backend := &fakeBackend{sessions: []Session{{ID: "my-app", Backend: "fake"}}}
+	m := &Manager{backend: backend, backends: []Backend{backend}, store: NewMetadataStoreAt(t.TempDir())}
+	if err := m.store.Save(Metadata{
+		Name:  "my-app",
+		Path:  dir,
+		Hooks: []config.Hooks{{OnExit: `echo "$ATELIER_HOOK $ATELIER_SESSION" > exit.log`}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Kill("my-app"); err != nil {
+		t.Fatalf("Kill failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "exit.log"))
+	if err != nil || string(content) != "on-exit my-app\n" {
+		t.Errorf("expected the on-exit hook to run, got %q (%v)", content, err)
+	}
+	if md, _ := m.store.Get("my-app"); md != nil {
+		t.Error("expected the metadata to be deleted")
+	}
+}
@@ -0,0 +1,90 @@
+package sessions
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InstanceSeparator separates a session name from its instance number,
+// as in "my-app:shell#2".
+const InstanceSeparator = "#"
+
+// SplitInstance splits a session name into the name of its parent target and
+// its instance number. Names without a number are instance 1.
+func SplitInstance(name string) (string, int) {
+	i := strings.LastIndex(name, InstanceSeparator)
+	if i < 0 {
+		return name, 1
+	}
+	n, err := strconv.Atoi(name[i+1:])
+	if err != nil || n < 2 {
+		return name, 1
+	}
+	return name[:i], n
+}
+
+// InstanceName returns the session name for the nth instance of a target.
+func InstanceName(name string, n int) string {
+	if n <= 1 {
+		return name
+	}
+	return name + InstanceSeparator + strconv.Itoa(n)
+}
+
+// NewInstance returns a copy of the target named for its lowest free
+// instance number, so attaching starts an independent session.
+func (m *Manager) NewInstance(t Target) (Target, error) {
+	live, err := m.listLive()
+	if err != nil {
+		return t, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	t.Name = freeInstance(t.Name, live)
+	return t, nil
+}
+
+// freeInstance returns the name of the lowest instance of a target that is
+// not among the live sessions. The unnumbered name is used when it is free.
+func freeInstance(name string, live []Session) string {
+	base, _ := SplitInstance(name)
+	used := make(map[int]bool)
+	for _, s := range live {
+		if b, n := SplitInstance(s.ID); b == base {
+			used[n] = true
+		}
+	}
+
+	n := 1
+	for used[n] {
+		n++
+	}
+	return InstanceName(base, n)
+}
+
+// sameTarget reports whether a session name is an instance of the base target.
+func sameTarget(name, base string) bool {
+	b, _ := SplitInstance(name)
+	return b == base
+}
+
+// groupInstances orders sessions so every instance follows its parent
+// target, keeping targets in their original order.
+func groupInstances(sessions []Session) {
+	first := make(map[string]int)
+	for i, s := range sessions {
+		base, _ := SplitInstance(s.ID)
+		if _, ok := first[base]; !ok {
+			first[base] = i
+		}
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		bi, ni := SplitInstance(sessions[i].ID)
+		bj, nj := SplitInstance(sessions[j].ID)
+		if bi != bj {
+			return first[bi] < first[bj]
+		}
+		return ni < nj
+	})
+}
@@ -0,0 +1,58 @@
+package sessions
+
+import "testing"
+
+func TestSplitInstance(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		n    int
+	}{
+		{"my-app:shell", "my-app:shell", 1},
+		{"my-app:shell#2", "my-app:shell", 2},
+		{"my-app#12", "my-app", 12},
+		{"my-app#x", "my-app#x", 1},
+		{"my-app#1", "my-app#1", 1},
+	}
+	for _, tt := range tests {
+		base, n := SplitInstance(tt.name)
+		if base != tt.base || n != tt.n {
+			t.Errorf("SplitInstance(%q) = %q, %d; want %q, %d", tt.name, base, n, tt.base, tt.n)
+		}
+	}
+
+	if got := InstanceName("my-app:shell", 3); got != "my-app:shell#3" {
+		t.Errorf("unexpected instance name %q", got)
+	}
+}
+
+func TestGroupInstances(t *testing.T) {
+	list := []Session{
+		{ID: "my-app:shell#3"},
+		{ID: "notes"},
+		{ID: "my-app:shell"},
+		{ID: "notes#2"},
+		{ID: "my-app:shell#2"},
+	}
+	groupInstances(list)
+
+	want := []string{"my-app:shell", "my-app:shell#2", "my-app:shell#3", "notes", "notes#2"}
+	for i, id := range want {
+		if list[i].ID != id {
+			t.Fatalf("expected order %v, got %v", want, list)
+		}
+	}
+}
+
+func TestFreeInstance(t *testing.T) {
+	live := []Session{{ID: "my-app:shell"}, {ID: "my-app:shell#3"}, {ID: "other#2"}}
+	if got := freeInstance("my-app:shell", live); got != "my-app:shell#2" {
+		t.Errorf("expected the gap at #2, got %q", got)
+	}
+	if got := freeInstance("my-app:shell#3", live[1:]); got != "my-app:shell" {
+		t.Errorf("expected the unnumbered name once it is free, got %q", got)
+	}
+	if got := freeInstance("other", live); got != "other" {
+		t.Errorf("expected an unused target to keep its name, got %q", got)
+	}
+}
@@ -0,0 +1,177 @@
+package sessions
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Metadata records how a session was created so it can be traced back
+// to its location and action.
+type Metadata struct {
+	Name    string         `json:"name" yaml:"name"`
+	Key     string         `json:"key,omitempty" yaml:"key,omitempty"`
+	Project string         `json:"project" yaml:"project"`
+	Source  string         `json:"source" yaml:"source"`
+	Action  string         `json:"action" yaml:"action"`
+	Command []string       `json:"command" yaml:"command"`
+	Path    string         `json:"path" yaml:"path"`
+	Backend string         `json:"backend" yaml:"backend"`
+	Host    string         `json:"host" yaml:"host"`
+	Hooks   []config.Hooks `json:"hooks,omitempty" yaml:"hooks,omitempty"`
+	// Env and EnvFiles are the session's environment settings (not the loaded values).
+	Env            map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	EnvFiles       []string          `json:"env_files,omitempty" yaml:"env_files,omitempty"`
+	CreatedAt      time.Time         `json:"created_at" yaml:"created_at"`
+	LastAttachedAt time.Time         `json:"last_attached_at,omitzero" yaml:"last_attached_at,omitempty"`
+	// AttachedBy holds the process IDs of atelier-go clients currently attached.
+	AttachedBy []int `json:"attached_by,omitempty" yaml:"attached_by,omitempty"`
+}
+
+// InUse reports whether an atelier-go client is still attached to the session.
+// Clients that exited without recording their detach are ignored.
+func (md Metadata) InUse() bool {
+	for _, pid := range md.AttachedBy {
+		if processAlive(pid) {
+			return true
+		}
+	}
+	return false
+}
+
+// attach records pid as attached, dropping clients that are gone.
+func (md *Metadata) attach(pid int) {
+	clients := []int{pid}
+	for _, p := range md.AttachedBy {
+		if p != pid && processAlive(p) {
+			clients = append(clients, p)
+		}
+	}
+	md.AttachedBy = clients
+}
+
+// detach records that pid is no longer attached.
+func (md *Metadata) detach(pid int) {
+	var clients []int
+	for _, p := range md.AttachedBy {
+		if p != pid {
+			clients = append(clients, p)
+		}
+	}
+	md.AttachedBy = clients
+}
+
+// processAlive reports whether a process with the given ID is running.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// MetadataStore persists session metadata as one JSON file per session.
+type MetadataStore struct {
+	dir string
+}
+
+// NewMetadataStore creates a store in the atelier-go XDG state directory.
+func NewMetadataStore() (*MetadataStore, error) {
+	dir, err := utils.GetStateSubdir("metadata")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	return &MetadataStore{dir: dir}, nil
+}
+
+// NewMetadataStoreAt creates a store rooted at the given directory.
+func NewMetadataStoreAt(dir string) *MetadataStore {
+	return &MetadataStore{dir: dir}
+}
+
+// Get returns the metadata for a session, or nil if none is recorded.
+func (s *MetadataStore) Get(name string) (*Metadata, error) {
+	content, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read metadata for %s: %w", name, err)
+	}
+
+	var md Metadata
+	if err := json.Unmarshal(content, &md); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata for %s: %w", name, err)
+	}
+	return &md, nil
+}
+
+// Save writes the metadata for a session, replacing any existing entry.
+func (s *MetadataStore) Save(md Metadata) error {
+	content, err := json.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata for %s: %w", md.Name, err)
+	}
+
+	// Write to a temporary file first so readers never see a partial entry.
+	tmp := s.path(md.Name) + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return fmt.Errorf("failed to write metadata for %s: %w", md.Name, err)
+	}
+	if err := os.Rename(tmp, s.path(md.Name)); err != nil {
+		return fmt.Errorf("failed to write metadata for %s: %w", md.Name, err)
+	}
+	return nil
+}
+
+// Delete removes the metadata for a session.
+func (s *MetadataStore) Delete(name string) error {
+	err := os.Remove(s.path(name))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete metadata for %s: %w", name, err)
+	}
+	return nil
+}
+
+// All returns every recorded entry keyed by session name.
+func (s *MetadataStore) All() (map[string]Metadata, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Metadata{}, nil
+		}
+		return nil, fmt.Errorf("failed to read metadata directory: %w", err)
+	}
+
+	all := make(map[string]Metadata, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		name, err := url.PathUnescape(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		md, err := s.Get(name)
+		if err != nil || md == nil {
+			continue
+		}
+		all[name] = *md
+	}
+	return all, nil
+}
+
+// path returns the file used for a session. Names are escaped so that
+// separators such as '/' cannot escape the store directory.
+func (s *MetadataStore) path(name string) string {
+	return filepath.Join(s.dir, url.PathEscape(name)+".json")
+}
@@ -0,0 +1,66 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadataStore(t *testing.T) {
+	store := NewMetadataStoreAt(t.TempDir())
+
+	md, err := store.Get("my-app:run-server")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if md != nil {
+		t.Fatalf("expected no metadata, got %+v", md)
+	}
+
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	entry := Metadata{
+		Name:           "my-app:run-server",
+		Project:        "My App",
+		Source:         "Project",
+		Action:         "Run Server",
+		Command:        []string{"/bin/zsh", "-l", "-i", "-c", "npm start"},
+		Path:           "/home/user/my-app",
+		Backend:        "zmx",
+		Host:           "workstation",
+		CreatedAt:      created,
+		LastAttachedAt: created,
+	}
+	if err := store.Save(entry); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	md, err = store.Get("my-app:run-server")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if md == nil || md.Project != "My App" || md.Action != "Run Server" || !md.CreatedAt.Equal(created) {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+
+	// Names with path separators must stay inside the store
+	if err := store.Save(Metadata{Name: "../escape/name"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	all, err := store.All()
+	if err != nil {
+		t.Fatalf("All failed: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(all))
+	}
+	if _, ok := all["../escape/name"]; !ok {
+		t.Errorf("expected escaped name to round-trip, got %v", all)
+	}
+
+	if err := store.Delete("my-app:run-server"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if md, _ := store.Get("my-app:run-server"); md != nil {
+		t.Errorf("expected metadata to be deleted, got %+v", md)
+	}
+}
@@ -0,0 +1,186 @@
+package sessions
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"atelier-go/internal/locations"
+	"atelier-go/internal/utils"
+)
+
+// DefaultNameTemplate names shells after the project and other actions
+// "project:action".
+const DefaultNameTemplate = `{{.Project}}{{if ne .Action "shell"}}:{{.Action}}{{end}}`
+
+// defaultNamer names targets for a Manager without configuration.
+var defaultNamer, _ = newNamer("")
+
+// NameData is the data available to the session-name template.
+// Every field is sanitized for use in a session name.
+type NameData struct {
+	// Project is the location name, with a short path hash appended when
+	// another location has the same name.
+	Project string
+	// Action is "shell", "editor", or the sanitized action name.
+	Action string
+	// Source is the provider the location came from, e.g. "project".
+	Source string
+	// Dir is the base name of the location's path.
+	Dir string
+	// Host is the short host name.
+	Host string
+}
+
+// namer assigns session names, keeping different paths from sharing a name.
+// Names shared by several known locations get a path hash, and project keys
+// already claimed by recorded sessions are loaded from metadata.
+type namer struct {
+	tmpl *template.Template
+
+	mu     sync.Mutex
+	loaded bool
+	keys   map[string]string // path -> project key in use
+	owners map[string]string // project key -> path that claimed it
+	shared map[string]bool   // sanitized names used by several known locations
+}
+
+// newNamer parses a session-name template, falling back to DefaultNameTemplate.
+func newNamer(text string) (*namer, error) {
+	if text == "" {
+		text = DefaultNameTemplate
+	}
+	tmpl, err := template.New("session-name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session-name template: %w", err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, NameData{}); err != nil {
+		return nil, fmt.Errorf("invalid session-name template: %w", err)
+	}
+	return &namer{tmpl: tmpl}, nil
+}
+
+// name renders the session name for a location and action, returning it
+// together with the project key it used.
+func (n *namer) name(loc locations.Location, action string, store *MetadataStore) (string, string) {
+	key := n.projectKey(loc, store)
+	host, _ := os.Hostname()
+	data := NameData{
+		Project: key,
+		Action:  utils.Sanitize(action),
+		Source:  utils.Sanitize(loc.Source),
+		Dir:     utils.Sanitize(filepath.Base(loc.Path)),
+		Host:    utils.Sanitize(strings.SplitN(host, ".", 2)[0]),
+	}
+
+	var buf bytes.Buffer
+	if err := n.tmpl.Execute(&buf, data); err != nil || strings.TrimSpace(buf.String()) == "" {
+		// The template was checked at construction; guard against empty output
+		buf.Reset()
+		buf.WriteString(key + ":" + data.Action)
+	}
+	return strings.TrimSpace(buf.String()), key
+}
+
+// projectKey returns the project component for a location's session names.
+// A path keeps the key it was first recorded with. Otherwise a name shared
+// with another known location, or claimed by a different path, gets a short
+// hash of this path appended, so each of them is named the same way every time.
+func (n *namer) projectKey(loc locations.Location, store *MetadataStore) string {
+	base := utils.Sanitize(loc.Name)
+	if loc.Path == "" {
+		return base
+	}
+	path := filepath.Clean(loc.Path)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.loaded && store != nil {
+		if all, err := store.All(); err == nil {
+			n.resetLocked(all)
+		}
+	}
+
+	if key, ok := n.keys[path]; ok && (key == base || strings.HasPrefix(key, base+"-")) {
+		return key
+	}
+	if n.shared[base] {
+		return base + "-" + utils.ShortHash(path)
+	}
+	if owner, ok := n.owners[base]; ok && owner != path {
+		return base + "-" + utils.ShortHash(path)
+	}
+	return base
+}
+
+// setLocations records which names are shared by more than one path among
+// the given locations.
+func (n *namer) setLocations(locs []locations.Location) {
+	paths := make(map[string]string)
+	shared := make(map[string]bool)
+	for _, loc := range locs {
+		if loc.Path == "" {
+			continue
+		}
+		base := utils.Sanitize(loc.Name)
+		path := filepath.Clean(loc.Path)
+		if seen, ok := paths[base]; ok && seen != path {
+			shared[base] = true
+		}
+		paths[base] = path
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.shared = shared
+}
+
+// refresh replaces the known claims with those in the given metadata.
+func (n *namer) refresh(all map[string]Metadata) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.resetLocked(all)
+}
+
+func (n *namer) resetLocked(all map[string]Metadata) {
+	n.loaded = true
+	n.keys = make(map[string]string)
+	n.owners = make(map[string]string)
+	for _, md := range all {
+		n.claimLocked(md)
+	}
+}
+
+// claim records that a session's path uses its project key.
+func (n *namer) claim(md Metadata) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.claimLocked(md)
+}
+
+func (n *namer) claimLocked(md Metadata) {
+	key := md.Key
+	if key == "" {
+		// Entries recorded before keys were tracked used the sanitized project name
+		key = utils.Sanitize(md.Project)
+	}
+	if key == "" || md.Path == "" {
+		return
+	}
+	if n.keys == nil {
+		n.keys = make(map[string]string)
+		n.owners = make(map[string]string)
+	}
+	path := filepath.Clean(md.Path)
+	if _, ok := n.keys[path]; !ok {
+		n.keys[path] = key
+	}
+	if _, ok := n.owners[key]; !ok {
+		n.owners[key] = path
+	}
+}
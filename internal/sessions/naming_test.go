@@ -0,0 +1,100 @@
+package sessions
+
+import (
+	"testing"
+
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/utils"
+)
+
+func TestNamerTemplate(t *testing.T) {
+	n, err := newNamer("")
+	if err != nil {
+		t.Fatalf("newNamer failed: %v", err)
+	}
+	loc := locations.Location{Name: "My App", Path: "/home/user/my-app", Source: "Project"}
+
+	if name, _ := n.name(loc, "Shell", nil); name != "my-app" {
+		t.Errorf("expected shell session my-app, got %s", name)
+	}
+	if name, _ := n.name(loc, "Run Server", nil); name != "my-app:run-server" {
+		t.Errorf("expected my-app:run-server, got %s", name)
+	}
+
+	n, err = newNamer("{{.Source}}/{{.Project}}:{{.Action}}")
+	if err != nil {
+		t.Fatalf("newNamer failed: %v", err)
+	}
+	if name, _ := n.name(loc, "Shell", nil); name != "project/my-app:shell" {
+		t.Errorf("expected project/my-app:shell, got %s", name)
+	}
+
+	for _, bad := range []string{"{{.Project", "{{.Missing}}"} {
+		if _, err := newNamer(bad); err == nil {
+			t.Errorf("expected error for template %q", bad)
+		}
+	}
+}
+
+func TestNamerKnowsConfiguredFields(t *testing.T) {
+	for _, field := range config.SessionNameFields {
+		if _, err := newNamer("{{." + field + "}}"); err != nil {
+			t.Errorf("field %s: %v", field, err)
+		}
+	}
+}
+
+func TestNamerCollisions(t *testing.T) {
+	store := NewMetadataStoreAt(t.TempDir())
+	if err := store.Save(Metadata{Name: "my-app", Key: "my-app", Project: "My App", Path: "/home/user/my-app"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	n, _ := newNamer("")
+
+	owner := locations.Location{Name: "My App", Path: "/home/user/my-app"}
+	other := locations.Location{Name: "my-app", Path: "/srv/my-app"}
+
+	if name, _ := n.name(owner, "Shell", store); name != "my-app" {
+		t.Errorf("expected the recorded path to keep my-app, got %s", name)
+	}
+
+	want := "my-app-" + utils.ShortHash("/srv/my-app")
+	name, key := n.name(other, "Run Server", store)
+	if key != want || name != want+":run-server" {
+		t.Errorf("expected %s:run-server, got %s (key %s)", want, name, key)
+	}
+
+	// Once the hashed key is recorded, it sticks even if the original owner goes away
+	n.refresh(map[string]Metadata{
+		want: {Name: want, Key: want, Project: "my-app", Path: "/srv/my-app"},
+	})
+	if name, _ := n.name(other, "Shell", store); name != want {
+		t.Errorf("expected %s to be kept, got %s", want, name)
+	}
+}
+
+func TestNamerSharedNames(t *testing.T) {
+	n, _ := newNamer("")
+	first := locations.Location{Name: "api", Path: "/home/user/work/api"}
+	second := locations.Location{Name: "api", Path: "/home/user/oss/api"}
+	n.setLocations([]locations.Location{first, second, {Name: "web", Path: "/home/user/web"}})
+
+	// Without any recorded session, both get a hash, whichever is named first
+	store := NewMetadataStoreAt(t.TempDir())
+	for _, loc := range []locations.Location{second, first} {
+		want := "api-" + utils.ShortHash(loc.Path)
+		if _, key := n.name(loc, "shell", store); key != want {
+			t.Errorf("expected %s for %s, got %s", want, loc.Path, key)
+		}
+	}
+	if _, key := n.name(locations.Location{Name: "web", Path: "/home/user/web"}, "shell", store); key != "web" {
+		t.Errorf("expected an unshared name to stay plain, got %s", key)
+	}
+
+	// A path keeps the key its running session was recorded with
+	n.refresh(map[string]Metadata{"api": {Name: "api", Key: "api", Project: "api", Path: first.Path}})
+	if _, key := n.name(first, "shell", store); key != "api" {
+		t.Errorf("expected the recorded key to be kept, got %s", key)
+	}
+}
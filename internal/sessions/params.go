@@ -0,0 +1,170 @@
+package sessions
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/utils"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// ErrMissingParam is returned when an action parameter without a default
+// has no value.
+var ErrMissingParam = errors.New("missing parameter")
+
+// ActionData is the data available to templates in an action's command,
+// args and cwd. In a command, every value is quoted for the shell.
+type ActionData struct {
+	// Path is the location's directory.
+	Path string
+	// Name is the location name.
+	Name string
+	// Host is the short host name.
+	Host string
+	// Params holds the values of the action's declared params.
+	Params map[string]string
+
+	dir   string // unquoted Path, for running git
+	quote bool
+}
+
+// GitBranch returns the branch checked out in the location, or "" outside a
+// git repository. It only runs git when a template uses it.
+func (d ActionData) GitBranch() string {
+	out, err := exec.Command("git", "-C", d.dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	branch := strings.TrimSpace(string(out))
+	if d.quote {
+		return shellQuote(branch)
+	}
+	return branch
+}
+
+// quoted returns a copy of the data with every value quoted for the shell.
+func (d ActionData) quoted() ActionData {
+	q := d
+	q.Path, q.Name, q.Host = shellQuote(d.Path), shellQuote(d.Name), shellQuote(d.Host)
+	q.Params = make(map[string]string, len(d.Params))
+	for k, v := range d.Params {
+		q.Params[k] = shellQuote(v)
+	}
+	q.quote = true
+	return q
+}
+
+// shellQuote quotes a value as a single shell word. Empty values stay empty,
+// so they can still be tested with {{if}}.
+func shellQuote(s string) string {
+	if s == "" {
+		return ""
+	}
+	return utils.ShellJoin([]string{s})
+}
+
+// ParseParams parses "key=value" pairs, as given to --param.
+func ParseParams(pairs []string) (map[string]string, error) {
+	params := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid param %q: expected key=value", p)
+		}
+		params[k] = v
+	}
+	return params, nil
+}
+
+// paramValues fills in an action's params from the given values and defaults.
+// Params without a value or default are an error.
+func paramValues(act config.Action, values map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(act.Params))
+	for k := range values {
+		if !slices.ContainsFunc(act.Params, func(p config.Param) bool { return p.Name == k }) {
+			return nil, fmt.Errorf("action %q has no parameter %q", act.Name, k)
+		}
+	}
+	for _, p := range act.Params {
+		v, ok := values[p.Name]
+		if !ok {
+			v = p.Default
+		}
+		if v == "" && p.Default == "" {
+			return nil, fmt.Errorf("action %q: %w %q", act.Name, ErrMissingParam, p.Name)
+		}
+		if v != "" && len(p.Choices) > 0 && !slices.Contains(p.Choices, v) {
+			return nil, fmt.Errorf("action %q: parameter %q must be one of %s", act.Name, p.Name, strings.Join(p.Choices, ", "))
+		}
+		out[p.Name] = v
+	}
+	return out, nil
+}
+
+// paramsName returns the session name of an action run with the given param
+// values, so runs with different values get sessions of their own.
+func paramsName(act config.Action, values map[string]string) string {
+	if len(values) == 0 {
+		return act.Name
+	}
+	pairs := make([]string, 0, len(values))
+	for k, v := range values {
+		pairs = append(pairs, k+"="+v)
+	}
+	slices.Sort(pairs)
+	return act.Name + " " + utils.ShortHash(strings.Join(pairs, "\n"))
+}
+
+// renderAction expands the templates in an action's command, args and cwd,
+// if the action uses templates.
+func renderAction(act config.Action, loc locations.Location, params map[string]string) (config.Action, error) {
+	if !act.UsesTemplate() {
+		return act, nil
+	}
+	host, _ := os.Hostname()
+	data := ActionData{
+		Path:   loc.Path,
+		Name:   loc.Name,
+		Host:   strings.SplitN(host, ".", 2)[0],
+		Params: params,
+		dir:    loc.Path,
+	}
+
+	render := func(field, text string, data ActionData) (string, error) {
+		if !strings.Contains(text, "{{") {
+			return text, nil
+		}
+		tmpl, err := template.New(field).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("action %q: invalid %s template: %w", act.Name, field, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("action %q: failed to render %s: %w", act.Name, field, err)
+		}
+		return buf.String(), nil
+	}
+
+	var err error
+	// The command runs through the shell, so values must not be able to split it
+	if act.Command, err = render("command", act.Command, data.quoted()); err != nil {
+		return act, err
+	}
+	if act.Cwd, err = render("cwd", act.Cwd, data); err != nil {
+		return act, err
+	}
+	args := make([]string, len(act.Args))
+	for i, a := range act.Args {
+		if args[i], err = render("args", a, data); err != nil {
+			return act, err
+		}
+	}
+	act.Args = args
+	return act, nil
+}
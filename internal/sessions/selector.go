@@ -0,0 +1,81 @@
+package sessions
+
+import (
+	"fmt"
+	"os"
+	"path"
+)
+
+// Selector describes which sessions a bulk operation applies to.
+// A session is selected when it matches any of the criteria.
+type Selector struct {
+	// All selects every session.
+	All bool
+	// Patterns are glob patterns matched against session IDs.
+	Patterns []string
+	// Names are exact session IDs, e.g. every target resolved for a project.
+	Names []string
+	// Project selects sessions whose metadata records this project name.
+	Project string
+	// Stale selects sessions whose recorded working directory no longer exists.
+	Stale bool
+}
+
+// Empty reports whether the selector has no criteria.
+func (s Selector) Empty() bool {
+	return !s.All && len(s.Patterns) == 0 && len(s.Names) == 0 && s.Project == "" && !s.Stale
+}
+
+// Validate checks that every pattern is a well-formed glob.
+func (s Selector) Validate() error {
+	for _, p := range s.Patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the session is selected.
+func (s Selector) Match(sess Session) bool {
+	if s.All {
+		return true
+	}
+	for _, p := range s.Patterns {
+		if ok, _ := path.Match(p, sess.ID); ok {
+			return true
+		}
+	}
+	for _, n := range s.Names {
+		if n == sess.ID {
+			return true
+		}
+	}
+	if s.Project != "" && sess.Metadata != nil && sess.Metadata.Project == s.Project {
+		return true
+	}
+	if s.Stale && isStale(sess) {
+		return true
+	}
+	return false
+}
+
+// Select returns the sessions matched by the selector, preserving order.
+func (s Selector) Select(sessions []Session) []Session {
+	var selected []Session
+	for _, sess := range sessions {
+		if s.Match(sess) {
+			selected = append(selected, sess)
+		}
+	}
+	return selected
+}
+
+// isStale reports whether the session's working directory has been removed.
+func isStale(sess Session) bool {
+	if sess.Path == "" {
+		return false
+	}
+	_, err := os.Stat(sess.Path)
+	return os.IsNotExist(err)
+}
@@ -0,0 +1,55 @@
+package sessions
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSelector_Select(t *testing.T) {
+	existing := t.TempDir()
+	missing := filepath.Join(existing, "removed")
+
+	all := []Session{
+		{ID: "my-app", Path: existing},
+		{ID: "my-app:run-server", Path: existing, Metadata: &Metadata{Project: "My App"}},
+		{ID: "other:editor", Path: missing},
+		{ID: "detached", Metadata: &Metadata{Project: "My App"}},
+	}
+
+	tests := []struct {
+		name     string
+		sel      Selector
+		expected []string
+	}{
+		{name: "all", sel: Selector{All: true}, expected: []string{"my-app", "my-app:run-server", "other:editor", "detached"}},
+		{name: "glob", sel: Selector{Patterns: []string{"my-app:*"}}, expected: []string{"my-app:run-server"}},
+		{name: "literal", sel: Selector{Patterns: []string{"my-app"}}, expected: []string{"my-app"}},
+		{name: "names", sel: Selector{Names: []string{"my-app", "my-app:editor"}}, expected: []string{"my-app"}},
+		{name: "project metadata", sel: Selector{Project: "My App"}, expected: []string{"my-app:run-server", "detached"}},
+		{name: "stale", sel: Selector{Stale: true}, expected: []string{"other:editor"}},
+		{name: "none", sel: Selector{}, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.sel.Select(all)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i, id := range tt.expected {
+				if got[i].ID != id {
+					t.Errorf("at index %d: expected %s, got %s", i, id, got[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSelector_Validate(t *testing.T) {
+	if err := (Selector{Patterns: []string{"my-app:*"}}).Validate(); err != nil {
+		t.Errorf("expected valid pattern, got %v", err)
+	}
+	if err := (Selector{Patterns: []string{"my-app:["}}).Validate(); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
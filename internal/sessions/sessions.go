@@ -5,22 +5,26 @@ import (
 	"atelier-go/internal/config"
 	"atelier-go/internal/env"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/output"
 	"atelier-go/internal/utils"
-	"bufio"
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
+	"time"
 )
 
 // Session represents a running workspace session.
 type Session struct {
-	ID   string
-	Path string
+	ID      string `json:"id" yaml:"id"`
+	Path    string `json:"path" yaml:"path"`
+	Backend string `json:"backend" yaml:"backend"`
+	// Attached is set when the backend reports a client connected to the session.
+	Attached bool `json:"attached,omitempty" yaml:"attached,omitempty"`
+	// Metadata is what atelier-go recorded when the session was created, if anything.
+	Metadata *Metadata `json:"metadata" yaml:"metadata"`
 }
 
 // Target represents a resolved session target ready for attachment.
@@ -28,18 +32,83 @@ type Target struct {
 	Name    string
 	Path    string
 	Command []string
+	Backend string // Empty selects the manager's default backend
+
+	// Key is the project component of Name. It is recorded so a path keeps
+	// its name when another path later claims the same project name.
+	Key string
+
+	// Origin of the target, recorded in the session metadata.
+	Project string
+	Source  string
+	Action  string
+
+	// Hooks run around attach, in order (project hooks, then action hooks).
+	Hooks []config.Hooks
+
+	// Env and EnvFiles hold the project and action environment, applied on
+	// top of the global environment when the session is created.
+	Env      map[string]string
+	EnvFiles []string
 }
 
-// Manager handles interaction with the zmx session manager.
-type Manager struct{}
+// Manager handles interaction with the configured session backends.
+type Manager struct {
+	backend  Backend
+	backends []Backend
+	store    *MetadataStore
+	names    *namer
+	env      map[string]string
+	envFiles []string
+}
+
+// NewManager creates a new session manager from the configuration.
+// The root session-backend becomes the default, and any per-project
+// overrides are registered so their sessions are listed too.
+func NewManager(cfg *config.Config) (*Manager, error) {
+	backend, err := NewBackend(cfg.GetSessionBackend())
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := NewMetadataStore()
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := newNamer(cfg.SessionName)
+	if err != nil {
+		return nil, err
+	}
 
-// NewManager creates a new session manager.
-func NewManager() *Manager {
-	return &Manager{}
+	m := &Manager{
+		backend:  backend,
+		backends: []Backend{backend},
+		store:    store,
+		names:    names,
+		env:      cfg.Env,
+		envFiles: cfg.EnvFiles,
+	}
+	seen := map[string]bool{backend.Name(): true}
+	for _, p := range cfg.Projects {
+		if p.SessionBackend == "" {
+			continue
+		}
+		b, err := NewBackend(p.SessionBackend)
+		if err != nil {
+			return nil, fmt.Errorf("project %q: %w", p.Name, err)
+		}
+		if !seen[b.Name()] {
+			m.backends = append(m.backends, b)
+			seen[b.Name()] = true
+		}
+	}
+	return m, nil
 }
 
-// Resolve converts a location and optional action into a concrete Target.
-func (m *Manager) Resolve(loc locations.Location, actionName string, shell string, editor string) (*Target, error) {
+// ResolveParams converts a location and optional action into a concrete Target,
+// with values for the action's params. Every param must have a value or a default.
+func (m *Manager) ResolveParams(loc locations.Location, actionName string, shell string, editor string, params map[string]string) (*Target, error) {
 	sanitizedAction := utils.Sanitize(actionName)
 
 	// 1. If an actionName is provided, try to find it in loc.Actions first.
@@ -47,25 +116,21 @@ func (m *Manager) Resolve(loc locations.Location, actionName string, shell strin
 	if actionName != "" {
 		for _, act := range loc.Actions {
 			if utils.Sanitize(act.Name) == sanitizedAction {
-				return m.resolveAction(loc, act, shell)
+				return m.resolveAction(loc, act, shell, params)
 			}
 		}
 
+		if len(params) > 0 {
+			return nil, fmt.Errorf("action %q does not take parameters", actionName)
+		}
+
 		// 2. Fallback to built-in behaviors if not found in loc.Actions
 		if sanitizedAction == "editor" {
-			return &Target{
-				Name:    utils.Sanitize(loc.Name) + ":editor",
-				Path:    loc.Path,
-				Command: env.BuildInteractiveWrapper(shell, editor+" ."),
-			}, nil
+			return m.newTarget(loc, "Editor", env.BuildInteractiveWrapper(shell, editor+" .")), nil
 		}
 
 		if sanitizedAction == "shell" {
-			return &Target{
-				Name:    utils.Sanitize(loc.Name),
-				Path:    loc.Path,
-				Command: env.BuildInteractiveWrapper(shell, ""),
-			}, nil
+			return m.newTarget(loc, "Shell", env.BuildInteractiveWrapper(shell, "")), nil
 		}
 
 		return nil, fmt.Errorf("action %q not found for %q", actionName, loc.Name)
@@ -73,114 +138,532 @@ func (m *Manager) Resolve(loc locations.Location, actionName string, shell strin
 
 	// 3. No actionName provided. Use default action (first one) if it exists.
 	if len(loc.Actions) > 0 {
-		return m.resolveAction(loc, loc.Actions[0], shell)
+		return m.resolveAction(loc, loc.Actions[0], shell, params)
+	}
+	if len(params) > 0 {
+		return nil, fmt.Errorf("%q has no action that takes parameters", loc.Name)
 	}
 
 	// 4. No actions exist, open a shell.
-	return &Target{
-		Name:    utils.Sanitize(loc.Name),
-		Path:    loc.Path,
-		Command: env.BuildInteractiveWrapper(shell, ""),
-	}, nil
+	return m.newTarget(loc, "Shell", env.BuildInteractiveWrapper(shell, "")), nil
 }
 
 // resolveAction creates a Target from a specific action.
-func (m *Manager) resolveAction(loc locations.Location, act config.Action, shell string) (*Target, error) {
-	name := fmt.Sprintf("%s:%s", utils.Sanitize(loc.Name), utils.Sanitize(act.Name))
-	// If the action is "Shell", don't add the suffix for consistency
-	if utils.Sanitize(act.Name) == "shell" {
-		name = utils.Sanitize(loc.Name)
+// Actions with args run the program directly rather than through the shell.
+func (m *Manager) resolveAction(loc locations.Location, act config.Action, shell string, params map[string]string) (*Target, error) {
+	values, err := paramValues(act, params)
+	if err != nil {
+		return nil, err
+	}
+	act, err = renderAction(act, loc, values)
+	if err != nil {
+		return nil, err
+	}
+
+	command := env.BuildInteractiveWrapper(shell, act.Command)
+	if len(act.Args) > 0 {
+		command = append([]string(nil), act.Args...)
+	}
+	t := m.newTarget(loc, paramsName(act, values), command)
+	t.Action = act.Name
+	if act.Cwd != "" {
+		dir, err := actionDir(loc.Path, act.Cwd)
+		if err != nil {
+			return nil, fmt.Errorf("action %q: %w", act.Name, err)
+		}
+		t.Path = dir
+	}
+	t.Hooks = append(t.Hooks, act.Hooks)
+	t.Env = config.MergeEnv(t.Env, act.Env)
+	t.EnvFiles = append(t.EnvFiles, act.EnvFiles...)
+	return t, nil
+}
+
+// actionDir resolves an action's cwd against the project path.
+func actionDir(root, cwd string) (string, error) {
+	dir, err := utils.ExpandPath(cwd)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand cwd %s: %w", cwd, err)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid cwd: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("cwd %s is not a directory", dir)
+	}
+	return dir, nil
+}
+
+// TargetNames returns the session names of the location's actions, including
+// the built-in shell and editor targets. Commands are not resolved, so this is
+// cheap enough to call while rendering.
+func (m *Manager) TargetNames(loc locations.Location) []string {
+	actionNames := []string{"Shell", "Editor"}
+	for _, act := range loc.Actions {
+		actionNames = append(actionNames, act.Name)
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, a := range actionNames {
+		name := m.SessionName(loc, a)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// SessionName returns the name of an action's session without resolving its
+// command. Runs of an action with params are named after it with a hash of
+// the values appended.
+func (m *Manager) SessionName(loc locations.Location, action string) string {
+	return m.newTarget(loc, action, nil).Name
+}
+
+// SetLocations tells the manager which locations are known, so that
+// locations sharing a name get distinct session names from the start.
+func (m *Manager) SetLocations(locs []locations.Location) {
+	m.names.setLocations(locs)
+}
+
+// ProjectKey returns the project component of the location's session names,
+// as recorded in their metadata.
+func (m *Manager) ProjectKey(loc locations.Location) string {
+	return m.newTarget(loc, "", nil).Key
+}
+
+// newTarget builds a Target for a location, naming its session and keeping
+// track of where it came from.
+func (m *Manager) newTarget(loc locations.Location, action string, command []string) *Target {
+	names, store := defaultNamer, (*MetadataStore)(nil)
+	if m != nil && m.names != nil {
+		names, store = m.names, m.store
 	}
+	name, key := names.name(loc, action, store)
 	return &Target{
-		Name:    name,
-		Path:    loc.Path,
-		Command: env.BuildInteractiveWrapper(shell, act.Command),
-	}, nil
+		Name:     name,
+		Key:      key,
+		Path:     loc.Path,
+		Command:  command,
+		Backend:  loc.SessionBackend,
+		Project:  loc.Name,
+		Source:   loc.Source,
+		Action:   action,
+		Hooks:    []config.Hooks{loc.Hooks},
+		Env:      loc.Env,
+		EnvFiles: append([]string(nil), loc.EnvFiles...),
+	}
 }
 
-// Attach connects to an existing zmx session or creates a new one with the given name.
-func (m *Manager) Attach(name string, dir string, args ...string) error {
-	utils.SetTerminalTitle(name)
+// Attach connects to the target's session, creating it if it does not exist.
+// The target's backend is used when set; otherwise the backend already running
+// a session with that name is used, falling back to the default backend.
+// Lifecycle hooks run around the attach; on-create and pre-attach failures abort it.
+func (m *Manager) Attach(t Target) error {
+	existing, running := m.find(t.Name)
+	if t.Backend == "" && running {
+		t.Backend = existing.Backend
+	}
 
-	cmdArgs := []string{"attach", name}
-	if len(args) > 0 {
-		cmdArgs = append(cmdArgs, args...)
+	backend, err := m.backendFor(t.Backend)
+	if err != nil {
+		return err
 	}
 
-	cmd := exec.Command("zmx", cmdArgs...)
-	cmd.Dir = dir
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	md, err := m.store.Get(t.Name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to read session metadata: %v\n", err)
+	}
+	if md != nil && running {
+		t = withMetadata(t, *md)
+	}
 
-	if err := cmd.Run(); err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
-			return nil
+	vars, err := m.environment(t)
+	if err != nil {
+		return err
+	}
+
+	if !running {
+		if err := runHooks(t, HookOnCreate, vars, true); err != nil {
+			return err
 		}
-		return fmt.Errorf("zmx session ended with error: %w", err)
+	}
+	if err := runHooks(t, HookPreAttach, vars, true); err != nil {
+		return err
 	}
 
-	return nil
+	if err := m.recordAttach(t, md, backend.Name(), running); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record session metadata: %v\n", err)
+	}
+
+	utils.SetTerminalTitle(t.Name)
+	if sw, ok := backend.(Switcher); ok && sw.Switches() {
+		// The client was switched to the session and nothing is known about
+		// the detach, so post-detach and on-exit hooks do not run
+		return backend.Attach(t.Name, t.Path, t.Command, vars)
+	}
+	attachErr := backend.Attach(t.Name, t.Path, t.Command, vars)
+	// The session was in use until now, so idle time counts from the detach
+	if err := m.recordDetach(t.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record session metadata: %v\n", err)
+	}
+
+	if err := runHooks(t, HookPostDetach, vars, true); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if hasHook(t, HookOnExit) && !m.SessionExists(t.Name) {
+		if err := runHooks(t, HookOnExit, vars, true); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	return attachErr
 }
 
-// List returns a list of active sessions.
-// It assumes 'zmx list' returns output where each line is "ID" or "ID\tPath".
-func (m *Manager) List() ([]Session, error) {
-	cmd := exec.Command("zmx", "list")
-	output, err := cmd.Output()
+// Start creates the target's session in the background without attaching to it.
+// It returns ErrRunning if the session already exists, and ErrUnsupported
+// if the backend cannot start detached sessions.
+func (m *Manager) Start(t Target) error {
+	if existing, running := m.find(t.Name); running {
+		return fmt.Errorf("session %s is already running on %s: %w", t.Name, existing.Backend, ErrRunning)
+	}
+
+	backend, err := m.backendFor(t.Backend)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sessions: %w", err)
+		return err
+	}
+	starter, ok := backend.(Starter)
+	if !ok {
+		return fmt.Errorf("cannot start %s in the background with %s: %w", t.Name, backend.Name(), ErrUnsupported)
 	}
 
-	var sessions []Session
-	scanner := bufio.NewScanner(bytes.NewReader(output))
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
+	vars, err := m.environment(t)
+	if err != nil {
+		return err
+	}
+	if err := runHooks(t, HookOnCreate, vars, false); err != nil {
+		return err
+	}
+	if err := starter.Start(t.Name, t.Path, t.Command, vars); err != nil {
+		return err
+	}
 
-		parts := strings.SplitN(line, "\t", 2)
-		id := parts[0]
-		// Handle session_name= prefix if present
-		id = strings.TrimPrefix(id, "session_name=")
+	// A started session has not been attached yet, so only its creation is recorded
+	md := newMetadata(t, backend.Name(), time.Now())
+	if err := m.store.Save(md); err != nil {
+		return fmt.Errorf("session started, but failed to record metadata: %w", err)
+	}
+	m.names.claim(md)
+	return nil
+}
 
-		sess := Session{ID: id}
-		if len(parts) > 1 {
-			sess.Path = parts[1]
+// withMetadata fills fields the target does not specify from recorded metadata,
+// so re-attaching by name still knows the session's origin and hooks.
+func withMetadata(t Target, md Metadata) Target {
+	if t.Path == "" {
+		t.Path = md.Path
+	}
+	if t.Project == "" {
+		t.Key, t.Project, t.Source, t.Action = md.Key, md.Project, md.Source, md.Action
+	}
+	if len(t.Hooks) == 0 {
+		t.Hooks = md.Hooks
+	}
+	if t.Env == nil && t.EnvFiles == nil {
+		t.Env, t.EnvFiles = md.Env, md.EnvFiles
+	}
+	return t
+}
+
+// hasHook reports whether any of the target's hooks handle the event.
+func hasHook(t Target, event HookEvent) bool {
+	for _, h := range t.Hooks {
+		if hookCommand(h, event) != "" {
+			return true
 		}
-		sessions = append(sessions, sess)
 	}
+	return false
+}
+
+// recordAttach updates the metadata entry for a session about to be attached.
+// A fresh entry is written when the session is not already running.
+func (m *Manager) recordAttach(t Target, md *Metadata, backend string, running bool) error {
+	now := time.Now()
+
+	if md == nil || !running {
+		fresh := newMetadata(t, backend, now)
+		md = &fresh
+	}
+	md.LastAttachedAt = now
+	md.attach(os.Getpid())
+
+	if err := m.store.Save(*md); err != nil {
+		return err
+	}
+	m.names.claim(*md)
+	return nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to parse session list: %w", err)
+// recordDetach stamps the last attach time of a session that was just
+// detached from, if it is still recorded, and marks this client as gone.
+func (m *Manager) recordDetach(name string) error {
+	md, err := m.store.Get(name)
+	if err != nil || md == nil {
+		return err
 	}
+	md.LastAttachedAt = time.Now()
+	md.detach(os.Getpid())
+	return m.store.Save(*md)
+}
+
+// newMetadata describes a session being created for the target.
+func newMetadata(t Target, backend string, now time.Time) Metadata {
+	host, _ := os.Hostname()
+	return Metadata{
+		Name:      t.Name,
+		Key:       t.Key,
+		Project:   t.Project,
+		Source:    t.Source,
+		Action:    t.Action,
+		Command:   t.Command,
+		Path:      t.Path,
+		Backend:   backend,
+		Host:      host,
+		Hooks:     t.Hooks,
+		Env:       t.Env,
+		EnvFiles:  t.EnvFiles,
+		CreatedAt: now,
+	}
+}
 
+// Metadata returns the recorded metadata for a session, or nil if none exists.
+func (m *Manager) Metadata(name string) (*Metadata, error) {
+	return m.store.Get(name)
+}
+
+// List returns the active sessions across every configured backend,
+// joined with any recorded metadata.
+// Backends other than the default are skipped when their tool is not installed.
+func (m *Manager) List() ([]Session, error) {
+	sessions, err := m.listLive()
+	if err != nil {
+		return nil, err
+	}
+	groupInstances(sessions)
+
+	// Metadata is best effort; live sessions are still reported without it.
+	all, err := m.store.All()
+	if err != nil {
+		return sessions, nil
+	}
+	m.names.refresh(all)
+	for i, s := range sessions {
+		if md, ok := all[s.ID]; ok {
+			sessions[i].Metadata = &md
+			if sessions[i].Path == "" {
+				sessions[i].Path = md.Path
+			}
+		}
+	}
 	return sessions, nil
 }
 
-// Kill terminates a session.
+// listLive queries every backend for running sessions.
+func (m *Manager) listLive() ([]Session, error) {
+	var sessions []Session
+	for _, b := range m.backends {
+		list, err := b.List()
+		if err != nil {
+			if b != m.backend && errors.Is(err, exec.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		sessions = append(sessions, list...)
+	}
+	return sessions, nil
+}
+
+// Kill terminates a session using the backend that owns it, then runs its
+// recorded on-exit hooks. The session is killed even if a hook fails.
 func (m *Manager) Kill(name string) error {
-	cmd := exec.Command("zmx", "kill", name)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to kill session %s: %w", name, err)
+	md, _ := m.store.Get(name)
+	if err := m.kill(name); err != nil {
+		return err
+	}
+	if err := m.runExitHooks(name, md); err != nil {
+		return fmt.Errorf("session %s killed, but %w", name, err)
 	}
 	return nil
 }
 
+// kill terminates a session and forgets its metadata.
+func (m *Manager) kill(name string) error {
+	existing, _ := m.find(name)
+	backend, err := m.backendFor(existing.Backend)
+	if err != nil {
+		return err
+	}
+	if err := backend.Kill(name); err != nil {
+		return err
+	}
+	return m.store.Delete(name)
+}
+
+// runExitHooks runs the on-exit hooks recorded for a session that has ended.
+func (m *Manager) runExitHooks(name string, md *Metadata) error {
+	if md == nil {
+		return nil
+	}
+	t := withMetadata(Target{Name: name}, *md)
+	if !hasHook(t, HookOnExit) {
+		return nil
+	}
+	// Hooks still run when an env file has gone missing since the session started
+	vars, _ := m.environment(t)
+	return runHooks(t, HookOnExit, vars, false)
+}
+
+// Rename changes a session's name and moves its metadata along with it.
+// It fails with ErrUnsupported when the owning backend cannot rename sessions.
+func (m *Manager) Rename(oldName, newName string) error {
+	if newName == "" || newName == oldName {
+		return fmt.Errorf("invalid new session name %q", newName)
+	}
+	if m.SessionExists(newName) {
+		return fmt.Errorf("session %q already exists", newName)
+	}
+
+	existing, _ := m.find(oldName)
+	backend, err := m.backendFor(existing.Backend)
+	if err != nil {
+		return err
+	}
+	renamer, ok := backend.(Renamer)
+	if !ok {
+		return fmt.Errorf("cannot rename %s session %s: %w", backend.Name(), oldName, ErrUnsupported)
+	}
+	if err := renamer.Rename(oldName, newName); err != nil {
+		return err
+	}
+
+	md, err := m.store.Get(oldName)
+	if err != nil || md == nil {
+		return err
+	}
+	md.Name = newName
+	if err := m.store.Save(*md); err != nil {
+		return err
+	}
+	return m.store.Delete(oldName)
+}
+
+// Restart kills a session and returns a target that recreates it with the
+// recorded command. The caller attaches to the returned target.
+func (m *Manager) Restart(name string) (*Target, error) {
+	md, err := m.store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if md == nil || len(md.Command) == 0 {
+		return nil, fmt.Errorf("cannot restart %s: no recorded command", name)
+	}
+
+	if err := m.kill(name); err != nil {
+		return nil, err
+	}
+	// The session is recreated right away, so a failing on-exit hook does not stop it
+	_ = m.runExitHooks(name, md)
+
+	return &Target{
+		Name:     md.Name,
+		Key:      md.Key,
+		Path:     md.Path,
+		Command:  md.Command,
+		Backend:  md.Backend,
+		Project:  md.Project,
+		Source:   md.Source,
+		Action:   md.Action,
+		Hooks:    md.Hooks,
+		Env:      md.Env,
+		EnvFiles: md.EnvFiles,
+	}, nil
+}
+
+// Send types text into a live session without attaching to it. With enter,
+// the text is submitted as a command. Backends without a way to send input
+// return ErrUnsupported.
+func (m *Manager) Send(name string, text string, enter bool) error {
+	existing, running := m.find(name)
+	if !running {
+		return fmt.Errorf("session %s is not running", name)
+	}
+
+	backend, err := m.backendFor(existing.Backend)
+	if err != nil {
+		return err
+	}
+	sender, ok := backend.(Sender)
+	if !ok {
+		return fmt.Errorf("cannot send input to %s: the %s backend does not support it: %w", name, backend.Name(), ErrUnsupported)
+	}
+	return sender.Send(name, text, enter)
+}
+
+// Capture returns the last lines of output from a running session.
+// It fails with ErrUnsupported when the owning backend cannot capture output.
+func (m *Manager) Capture(name string, lines int) (string, error) {
+	existing, _ := m.find(name)
+	backend, err := m.backendFor(existing.Backend)
+	if err != nil {
+		return "", err
+	}
+	capturer, ok := backend.(Capturer)
+	if !ok {
+		return "", fmt.Errorf("cannot capture %s session %s: %w", backend.Name(), name, ErrUnsupported)
+	}
+	return capturer.Capture(name, lines)
+}
+
 // SessionExists checks if a session with the given ID is currently running.
 func (m *Manager) SessionExists(sessionID string) bool {
-	sessions, err := m.List()
+	_, ok := m.find(sessionID)
+	return ok
+}
+
+// find returns the running session with the given ID, if any.
+func (m *Manager) find(sessionID string) (Session, bool) {
+	sessions, err := m.listLive()
 	if err != nil {
-		return false
+		return Session{}, false
 	}
 	for _, s := range sessions {
 		if s.ID == sessionID {
-			return true
+			return s, true
 		}
 	}
-	return false
+	return Session{}, false
+}
+
+// backendFor returns the backend with the given name, or the default backend
+// when the name is empty.
+func (m *Manager) backendFor(name string) (Backend, error) {
+	if name == "" {
+		return m.backend, nil
+	}
+	for _, b := range m.backends {
+		if b.Name() == name {
+			return b, nil
+		}
+	}
+	return NewBackend(name)
 }
 
 // SaveState writes the current session ID to the state file for the given client.
@@ -221,6 +704,35 @@ func ClearState(clientID string) error {
 	return nil
 }
 
+// Renderer describes how sessions are printed in each output format.
+func (m *Manager) Renderer() output.Renderer[Session] {
+	return output.Renderer[Session]{
+		Kind:  "sessions",
+		Table: m.PrintTable,
+		Headers: []string{
+			"ID", "PATH", "BACKEND", "PROJECT", "SOURCE", "ACTION", "HOST", "CREATED_AT", "LAST_ATTACHED_AT",
+		},
+		Row: func(s Session) []string {
+			row := []string{s.ID, s.Path, s.Backend, "", "", "", "", "", ""}
+			if md := s.Metadata; md != nil {
+				copy(row[3:], []string{
+					md.Project, md.Source, md.Action, md.Host,
+					formatTime(md.CreatedAt), formatTime(md.LastAttachedAt),
+				})
+			}
+			return row
+		},
+	}
+}
+
+// formatTime formats a timestamp for output, leaving times never set empty.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // PrintTable formats and prints the sessions to the provided writer in a table format.
 func (m *Manager) PrintTable(w io.Writer, sessions []Session) error {
 	if len(sessions) == 0 {
@@ -230,11 +742,20 @@ func (m *Manager) PrintTable(w io.Writer, sessions []Session) error {
 		return nil
 	}
 
-	headers := []string{"ID", "PATH"}
+	headers := []string{"ID", "PROJECT", "ACTION", "PATH", "BACKEND"}
 	var rows [][]string
 
-	for _, s := range sessions {
-		rows = append(rows, []string{s.ID, s.Path})
+	for i, s := range sessions {
+		project, action := "-", "-"
+		if s.Metadata != nil {
+			project, action = s.Metadata.Project, s.Metadata.Action
+		}
+		id := s.ID
+		if base, n := SplitInstance(s.ID); n > 1 && i > 0 && sameTarget(sessions[i-1].ID, base) {
+			// Indent instances listed under their parent target
+			id = "  " + s.ID
+		}
+		rows = append(rows, []string{id, project, action, s.Path, s.Backend})
 	}
 
 	return utils.RenderTable(w, headers, rows)
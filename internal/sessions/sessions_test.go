@@ -0,0 +1,173 @@
+package sessions
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/output"
+)
+
+func TestResolveAction(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "web"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var m *Manager
+	loc := locations.Location{Name: "Mono", Path: root, Actions: []config.Action{
+		{Name: "dev", Command: "npm run dev", Cwd: "web"},
+		{Name: "logs", Args: []string{"tail", "-f", "app.log"}},
+		{Name: "broken", Command: "make", Cwd: "missing"},
+	}}
+
+	dev, err := m.ResolveParams(loc, "dev", "/bin/sh", "", nil)
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if dev.Path != filepath.Join(root, "web") {
+		t.Errorf("Path = %q, want the cwd under the project", dev.Path)
+	}
+	if dev.Command[0] != "/bin/sh" {
+		t.Errorf("Command = %v, want the shell wrapper", dev.Command)
+	}
+
+	logs, err := m.ResolveParams(loc, "logs", "/bin/sh", "", nil)
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if !reflect.DeepEqual(logs.Command, []string{"tail", "-f", "app.log"}) {
+		t.Errorf("Command = %v, want args run directly", logs.Command)
+	}
+	if logs.Path != root {
+		t.Errorf("Path = %q, want the project path", logs.Path)
+	}
+
+	if _, err := m.ResolveParams(loc, "broken", "/bin/sh", "", nil); err == nil {
+		t.Error("expected an error for a missing cwd")
+	}
+}
+
+func TestResolveParams(t *testing.T) {
+	var m *Manager
+	loc := locations.Location{Name: "api", Path: "/src/api", Actions: []config.Action{
+		{Name: "test", Args: []string{"go", "test", "./{{.Params.pkg}}/...", "-run={{.Params.run}}"}, Params: []config.Param{
+			{Name: "pkg", Prompt: "Package to test"},
+			{Name: "run", Default: "."},
+		}},
+		{Name: "mode", Args: []string{"run", "{{.Name}}", "{{.Params.env}}"}, Params: []config.Param{
+			{Name: "env", Default: "dev", Choices: []string{"dev", "prod"}},
+		}},
+	}}
+
+	target, err := m.ResolveParams(loc, "test", "/bin/sh", "", map[string]string{"pkg": "internal"})
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if want := []string{"go", "test", "./internal/...", "-run=."}; !reflect.DeepEqual(target.Command, want) {
+		t.Errorf("Command = %v, want %v", target.Command, want)
+	}
+
+	if _, err := m.ResolveParams(loc, "test", "/bin/sh", "", nil); !errors.Is(err, ErrMissingParam) {
+		t.Errorf("expected ErrMissingParam, got %v", err)
+	}
+
+	// Different values run in different sessions
+	other, err := m.ResolveParams(loc, "test", "/bin/sh", "", map[string]string{"pkg": "cmd"})
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if other.Name == target.Name || !strings.HasPrefix(other.Name, "api:test-") || other.Action != "test" {
+		t.Errorf("expected a separate session per param set, got %q and %q", target.Name, other.Name)
+	}
+
+	target, err = m.ResolveParams(loc, "mode", "/bin/sh", "", nil)
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if want := []string{"run", "api", "dev"}; !reflect.DeepEqual(target.Command, want) {
+		t.Errorf("Command = %v, want %v", target.Command, want)
+	}
+	if _, err := m.ResolveParams(loc, "mode", "/bin/sh", "", map[string]string{"env": "staging"}); err == nil {
+		t.Error("expected an error for a value outside the choices")
+	}
+	if _, err := m.ResolveParams(loc, "mode", "/bin/sh", "", map[string]string{"typo": "x"}); err == nil {
+		t.Error("expected an error for an unknown parameter")
+	}
+}
+
+func TestResolveQuotesCommandValues(t *testing.T) {
+	var m *Manager
+	loc := locations.Location{Name: "api", Path: "/src/my api", Actions: []config.Action{
+		{Name: "grep", Command: "cd {{.Path}} && grep -r {{.Params.pattern}} .{{if .Params.extra}} {{.Params.extra}}{{end}}", Params: []config.Param{
+			{Name: "pattern"},
+			{Name: "extra", Default: "-n"},
+		}},
+	}}
+	// Values are quoted, so they are never run as shell code
+	target, err := m.ResolveParams(loc, "grep", "/bin/sh", "", map[string]string{"pattern": "x; rm -rf $(pwd)"})
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	want := `cd '/src/my api' && grep -r 'x; rm -rf $(pwd)' . -n`
+	if !strings.Contains(strings.Join(target.Command, " "), want) {
+		t.Errorf("Command = %v, want it to run %s", target.Command, want)
+	}
+}
+
+func TestResolveLiteralBraces(t *testing.T) {
+	var m *Manager
+	loc := locations.Location{Name: "api", Path: "/src/api", Actions: []config.Action{
+		{Name: "ps", Args: []string{"docker", "ps", "--format", "{{.Names}}"}},
+	}}
+	target, err := m.ResolveParams(loc, "ps", "/bin/sh", "", nil)
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if want := []string{"docker", "ps", "--format", "{{.Names}}"}; !reflect.DeepEqual(target.Command, want) {
+		t.Errorf("Command = %v, want %v", target.Command, want)
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	params, err := ParseParams([]string{"pkg=internal/ui", "filter=a=b"})
+	if err != nil {
+		t.Fatalf("ParseParams failed: %v", err)
+	}
+	if want := map[string]string{"pkg": "internal/ui", "filter": "a=b"}; !reflect.DeepEqual(params, want) {
+		t.Errorf("ParseParams = %v, want %v", params, want)
+	}
+	if _, err := ParseParams([]string{"novalue"}); err == nil {
+		t.Error("expected an error without '='")
+	}
+}
+
+func TestRendererNeverAttached(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	list := []Session{{ID: "api", Backend: "zmx", Metadata: &Metadata{Name: "api", CreatedAt: created}}}
+	m := &Manager{}
+
+	var buf bytes.Buffer
+	if err := output.Write(&buf, output.Options{Format: output.FormatTSV}, m.Renderer(), list); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	row := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")[1]
+	if fields := strings.Split(row, "\t"); fields[7] != "2026-01-02T03:04:05Z" || fields[8] != "" {
+		t.Errorf("expected an empty last attached time, got %q", row)
+	}
+
+	buf.Reset()
+	if err := output.Write(&buf, output.Options{Format: output.FormatJSON}, m.Renderer(), list); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "last_attached_at") || strings.Contains(buf.String(), "0001-01-01") {
+		t.Errorf("expected no last attached time in json, got %s", buf.String())
+	}
+}
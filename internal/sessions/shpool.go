@@ -0,0 +1,88 @@
+package sessions
+
+import (
+	"atelier-go/internal/utils"
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ShpoolBackend implements Backend using shpool.
+type ShpoolBackend struct{}
+
+// Name returns the backend name.
+func (b *ShpoolBackend) Name() string {
+	return "shpool"
+}
+
+// Attach connects to an existing shpool session or creates a new one with the given name.
+// Sessions are spawned by the shpool daemon rather than the client, so vars are
+// loaded from a private file by the session's command instead.
+func (b *ShpoolBackend) Attach(name string, dir string, command []string, vars []string) error {
+	command, file, err := sourceEnv(vars, command)
+	if err != nil {
+		return err
+	}
+	if file != "" {
+		// Removed by the session itself; this covers attaching to one that was already running
+		defer func() { _ = os.Remove(file) }()
+	}
+
+	args := []string{"attach"}
+	if dir != "" {
+		args = append(args, "--dir", dir)
+	}
+	if len(command) > 0 {
+		args = append(args, "--cmd", utils.ShellJoin(command))
+	}
+	args = append(args, name)
+
+	if err := newInteractiveCmd(dir, "shpool", args...).Run(); err != nil {
+		return fmt.Errorf("shpool session ended with error: %w", err)
+	}
+	return nil
+}
+
+// List returns the active shpool sessions.
+func (b *ShpoolBackend) List() ([]Session, error) {
+	output, err := exec.Command("shpool", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shpool sessions: %w", err)
+	}
+	return parseShpoolList(output)
+}
+
+// Kill terminates a shpool session.
+func (b *ShpoolBackend) Kill(name string) error {
+	if err := exec.Command("shpool", "kill", name).Run(); err != nil {
+		return fmt.Errorf("failed to kill shpool session %s: %w", name, err)
+	}
+	return nil
+}
+
+// parseShpoolList parses 'shpool list' output, a tab-separated table
+// with a "NAME STARTED_AT STATUS" header.
+func parseShpoolList(output []byte) ([]Session, error) {
+	var sessions []Session
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "NAME") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		sess := Session{ID: fields[0], Backend: "shpool"}
+		sess.Attached = len(fields) > 2 && fields[len(fields)-1] == "attached"
+		sessions = append(sessions, sess)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse session list: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,181 @@
+package sessions
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// TmuxBackend implements Backend using tmux.
+// Session names are escaped with tmuxName, since tmux does not allow ':' or '.' in them.
+type TmuxBackend struct{}
+
+// Name returns the backend name.
+func (b *TmuxBackend) Name() string {
+	return "tmux"
+}
+
+// Attach connects to an existing tmux session or creates a new one with the given name.
+// When already running inside tmux, the client is switched instead of nesting.
+// Sessions are spawned by the tmux server, so vars are loaded from a private
+// file by the session's command, as with shpool.
+func (b *TmuxBackend) Attach(name string, dir string, command []string, vars []string) error {
+	target := tmuxName(name)
+
+	if os.Getenv("TMUX") != "" {
+		if exec.Command("tmux", "has-session", "-t", "="+target).Run() != nil {
+			if err := b.Start(name, dir, command, vars); err != nil {
+				return err
+			}
+		}
+		if err := newInteractiveCmd(dir, "tmux", "switch-client", "-t", "="+target).Run(); err != nil {
+			return fmt.Errorf("failed to switch tmux client: %w", err)
+		}
+		return nil
+	}
+
+	command, file, err := sourceEnv(vars, command)
+	if err != nil {
+		return err
+	}
+	if file != "" {
+		// Removed by the session itself; this covers attaching to one that was already running
+		defer func() { _ = os.Remove(file) }()
+	}
+
+	args := append([]string{"new-session", "-A", "-s", target, "-c", dir}, command...)
+	if err := newInteractiveCmd(dir, "tmux", args...).Run(); err != nil {
+		return fmt.Errorf("tmux session ended with error: %w", err)
+	}
+	return nil
+}
+
+// Switches reports whether Attach switches the current tmux client instead of
+// running a client until the user detaches.
+func (b *TmuxBackend) Switches() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// Start creates a detached tmux session.
+func (b *TmuxBackend) Start(name string, dir string, command []string, vars []string) error {
+	command, file, err := sourceEnv(vars, command)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"new-session", "-d", "-s", tmuxName(name), "-c", dir}, command...)
+	if err := runDetached(dir, nil, "tmux", args...); err != nil {
+		if file != "" {
+			_ = os.Remove(file)
+		}
+		return fmt.Errorf("failed to start tmux session %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the active tmux sessions.
+// A missing tmux server is reported as an empty list.
+func (b *TmuxBackend) List() ([]Session, error) {
+	output, err := exec.Command("tmux", "list-sessions", "-F", "#{session_name}\t#{session_path}\t#{session_attached}").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && isTmuxNoServer(string(exitErr.Stderr)) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list tmux sessions: %w", err)
+	}
+	return parseTmuxList(output)
+}
+
+// Kill terminates a tmux session.
+func (b *TmuxBackend) Kill(name string) error {
+	if err := exec.Command("tmux", "kill-session", "-t", "="+tmuxName(name)).Run(); err != nil {
+		return fmt.Errorf("failed to kill tmux session %s: %w", name, err)
+	}
+	return nil
+}
+
+// Rename changes the name of a running tmux session.
+func (b *TmuxBackend) Rename(oldName, newName string) error {
+	if err := exec.Command("tmux", "rename-session", "-t", "="+tmuxName(oldName), tmuxName(newName)).Run(); err != nil {
+		return fmt.Errorf("failed to rename tmux session %s: %w", oldName, err)
+	}
+	return nil
+}
+
+// Send types text into the session's active pane, optionally pressing Enter.
+func (b *TmuxBackend) Send(name string, text string, enter bool) error {
+	target := "=" + tmuxName(name) + ":"
+	if err := runDetached("", nil, "tmux", "send-keys", "-t", target, "-l", text); err != nil {
+		return fmt.Errorf("failed to send to tmux session %s: %w", name, err)
+	}
+	if enter {
+		if err := runDetached("", nil, "tmux", "send-keys", "-t", target, "Enter"); err != nil {
+			return fmt.Errorf("failed to send to tmux session %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Capture returns the last lines of the session's active pane.
+func (b *TmuxBackend) Capture(name string, lines int) (string, error) {
+	out, err := exec.Command("tmux", "capture-pane", "-p", "-J", "-t", "="+tmuxName(name)+":", "-S", fmt.Sprintf("-%d", lines)).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture tmux session %s: %w", name, err)
+	}
+	return lastLines(string(out), lines), nil
+}
+
+// tmuxEscaper percent-encodes the characters tmux does not allow in session
+// names, and "%" itself so the encoding can be reversed.
+var tmuxEscaper = strings.NewReplacer("%", "%25", ":", "%3A", ".", "%2E")
+
+// tmuxUnescaper reverses tmuxEscaper.
+var tmuxUnescaper = strings.NewReplacer("%25", "%", "%3A", ":", "%2E", ".")
+
+// tmuxName converts a session name into one accepted by tmux.
+func tmuxName(name string) string {
+	return tmuxEscaper.Replace(name)
+}
+
+// fromTmuxName reverses tmuxName.
+func fromTmuxName(name string) string {
+	return tmuxUnescaper.Replace(name)
+}
+
+func isTmuxNoServer(stderr string) bool {
+	return strings.Contains(stderr, "no server running") || strings.Contains(stderr, "error connecting to")
+}
+
+// parseTmuxList parses 'tmux list-sessions' output formatted as "Name\tPath".
+func parseTmuxList(output []byte) ([]Session, error) {
+	var sessions []Session
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "\t", 3)
+		sess := Session{ID: fromTmuxName(parts[0]), Backend: "tmux"}
+		if len(parts) > 1 {
+			sess.Path = parts[1]
+		}
+		if len(parts) > 2 {
+			// session_attached is the number of attached clients
+			sess.Attached = parts[2] != "0" && parts[2] != ""
+		}
+		sessions = append(sessions, sess)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse session list: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,110 @@
+package sessions
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ZmxBackend implements Backend using zmx.
+type ZmxBackend struct{}
+
+// Name returns the backend name.
+func (b *ZmxBackend) Name() string {
+	return "zmx"
+}
+
+// Attach connects to an existing zmx session or creates a new one with the given name.
+func (b *ZmxBackend) Attach(name string, dir string, command []string, vars []string) error {
+	cmdArgs := append([]string{"attach", name}, command...)
+
+	cmd := newInteractiveCmd(dir, "zmx", cmdArgs...)
+	cmd.Env = processEnv(vars)
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil
+		}
+		return fmt.Errorf("zmx session ended with error: %w", err)
+	}
+
+	return nil
+}
+
+// Start creates a zmx session running command without attaching to it.
+func (b *ZmxBackend) Start(name string, dir string, command []string, vars []string) error {
+	args := append([]string{"run", name}, command...)
+	if err := runDetached(dir, vars, "zmx", args...); err != nil {
+		return fmt.Errorf("failed to start zmx session %s: %w", name, err)
+	}
+	return nil
+}
+
+// List returns the active zmx sessions.
+func (b *ZmxBackend) List() ([]Session, error) {
+	output, err := exec.Command("zmx", "list").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zmx sessions: %w", err)
+	}
+	return parseZmxList(output)
+}
+
+// Kill terminates a zmx session.
+func (b *ZmxBackend) Kill(name string) error {
+	if err := exec.Command("zmx", "kill", name).Run(); err != nil {
+		return fmt.Errorf("failed to kill zmx session %s: %w", name, err)
+	}
+	return nil
+}
+
+// Send runs text as a command in the session's shell.
+// zmx cannot type input without submitting it.
+func (b *ZmxBackend) Send(name string, text string, enter bool) error {
+	if !enter {
+		return fmt.Errorf("zmx can only send complete commands: %w", ErrUnsupported)
+	}
+	if err := runDetached("", nil, "zmx", "run", name, text); err != nil {
+		return fmt.Errorf("failed to send to zmx session %s: %w", name, err)
+	}
+	return nil
+}
+
+// Capture returns the last lines of the session's scrollback history.
+func (b *ZmxBackend) Capture(name string, lines int) (string, error) {
+	out, err := exec.Command("zmx", "history", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read zmx history for %s: %w", name, err)
+	}
+	return lastLines(string(out), lines), nil
+}
+
+// parseZmxList parses 'zmx list' output where each line is "ID" or "ID\tPath".
+func parseZmxList(output []byte) ([]Session, error) {
+	var sessions []Session
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "\t", 2)
+		// Handle session_name= prefix if present
+		id := strings.TrimPrefix(parts[0], "session_name=")
+
+		sess := Session{ID: id, Backend: "zmx"}
+		if len(parts) > 1 {
+			sess.Path = parts[1]
+		}
+		sessions = append(sessions, sess)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse session list: %w", err)
+	}
+
+	return sessions, nil
+}
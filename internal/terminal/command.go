@@ -0,0 +1,65 @@
+package terminal
+
+import (
+	"atelier-go/internal/utils"
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// CommandDriver implements Driver by running a user-supplied shell command
+// template for every pane, e.g. "alacritty --working-directory {{.Dir}} -e {{.Command}}".
+// Each pane opens in its own window.
+type CommandDriver struct {
+	tmpl *template.Template
+}
+
+// commandData is the data available to the command template.
+type commandData struct {
+	Title string
+	// Dir is the shell-quoted working directory.
+	Dir string
+	// Command is the shell-quoted command to run.
+	Command string
+}
+
+// NewCommandDriver parses the command template.
+func NewCommandDriver(text string) (*CommandDriver, error) {
+	tmpl, err := template.New("terminal-command").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid terminal command template: %w", err)
+	}
+	return &CommandDriver{tmpl: tmpl}, nil
+}
+
+// Name returns the driver name.
+func (d *CommandDriver) Name() string {
+	return "command"
+}
+
+// OpenTab runs the command template to open a new window.
+func (d *CommandDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	line, err := d.render(title, dir, command)
+	if err != nil {
+		return "", err
+	}
+	return "", start(dir, "/bin/sh", "-c", line)
+}
+
+// Split is not supported by the command driver.
+func (d *CommandDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	return "", ErrUnsupported
+}
+
+func (d *CommandDriver) render(title, dir string, command []string) (string, error) {
+	var buf bytes.Buffer
+	data := commandData{
+		Title:   utils.ShellJoin([]string{title}),
+		Dir:     utils.ShellJoin([]string{dir}),
+		Command: utils.ShellJoin(command),
+	}
+	if err := d.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render terminal command: %w", err)
+	}
+	return buf.String(), nil
+}
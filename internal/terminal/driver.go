@@ -0,0 +1,120 @@
+// Package terminal opens commands in native terminal tabs and splits.
+package terminal
+
+import (
+	"atelier-go/internal/config"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrUnsupported is returned when a terminal driver cannot perform an operation.
+var ErrUnsupported = errors.New("operation not supported by terminal driver")
+
+// Direction is where a split opens relative to the pane it splits.
+type Direction string
+
+const (
+	// Right opens the new pane beside the existing one.
+	Right Direction = "right"
+	// Down opens the new pane below the existing one.
+	Down Direction = "down"
+)
+
+// Pane identifies a pane opened by a driver, e.g. a kitty window id.
+// Drivers that cannot address panes return an empty Pane.
+type Pane string
+
+// Driver defines the interface for terminals that can be scripted to open
+// tabs and splits.
+type Driver interface {
+	// Name returns the driver identifier used in configuration.
+	Name() string
+	// OpenTab opens a new tab (or window) in dir running command.
+	OpenTab(title, dir string, command []string) (Pane, error)
+	// Split opens a new pane next to an existing one, running command.
+	// Drivers without splits return ErrUnsupported.
+	Split(pane Pane, direction Direction, dir string, command []string) (Pane, error)
+}
+
+// NewDriver returns the driver selected in the configuration, detecting the
+// current terminal when the driver is "auto" or unset.
+func NewDriver(cfg config.Terminal) (Driver, error) {
+	name := cfg.Driver
+	if name == "" || name == "auto" {
+		name = Detect()
+		if name == "" && cfg.Command != "" {
+			name = "command"
+		}
+		if name == "" {
+			return nil, fmt.Errorf("no supported terminal detected; set terminal.driver in the config")
+		}
+	}
+
+	switch name {
+	case "kitty":
+		return &KittyDriver{}, nil
+	case "wezterm":
+		return &WeztermDriver{}, nil
+	case "ghostty":
+		return &GhosttyDriver{}, nil
+	case "gnome-terminal":
+		return &GnomeTerminalDriver{}, nil
+	case "konsole":
+		return &KonsoleDriver{}, nil
+	case "command":
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("terminal driver \"command\" requires terminal.command")
+		}
+		return NewCommandDriver(cfg.Command)
+	default:
+		return nil, fmt.Errorf("unknown terminal driver: %s", name)
+	}
+}
+
+// Detect returns the driver for the terminal this process runs in, or an
+// empty string when it is not recognized.
+func Detect() string {
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "":
+		return "kitty"
+	case os.Getenv("WEZTERM_PANE") != "":
+		return "wezterm"
+	case os.Getenv("TERM_PROGRAM") == "ghostty", os.Getenv("GHOSTTY_RESOURCES_DIR") != "":
+		return "ghostty"
+	case os.Getenv("KONSOLE_VERSION") != "":
+		return "konsole"
+	case os.Getenv("GNOME_TERMINAL_SCREEN") != "":
+		return "gnome-terminal"
+	}
+	return ""
+}
+
+// output runs a terminal's control command and returns its trimmed output,
+// including stderr in the error when it fails.
+func output(name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s: %w", name, msg, err)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// start launches a terminal process without waiting for it, for terminals
+// whose launcher stays alive as long as the window it opens.
+func start(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+	return cmd.Process.Release()
+}
@@ -0,0 +1,45 @@
+package terminal
+
+import (
+	"atelier-go/internal/config"
+	"testing"
+)
+
+func TestNewDriver(t *testing.T) {
+	for _, k := range []string{"KITTY_WINDOW_ID", "WEZTERM_PANE", "TERM_PROGRAM", "GHOSTTY_RESOURCES_DIR", "KONSOLE_VERSION", "GNOME_TERMINAL_SCREEN"} {
+		t.Setenv(k, "")
+	}
+
+	if _, err := NewDriver(config.Terminal{}); err == nil {
+		t.Error("expected an error when no terminal is detected")
+	}
+
+	t.Setenv("WEZTERM_PANE", "3")
+	if d, err := NewDriver(config.Terminal{Driver: "auto"}); err != nil || d.Name() != "wezterm" {
+		t.Errorf("expected wezterm to be detected, got %v (%v)", d, err)
+	}
+	if d, err := NewDriver(config.Terminal{Driver: "kitty"}); err != nil || d.Name() != "kitty" {
+		t.Errorf("expected kitty driver, got %v (%v)", d, err)
+	}
+	if _, err := NewDriver(config.Terminal{Driver: "command"}); err == nil {
+		t.Error("expected command driver to require a command")
+	}
+	if _, err := NewDriver(config.Terminal{Driver: "xterm2000"}); err == nil {
+		t.Error("expected error for unknown driver")
+	}
+}
+
+func TestCommandDriverRender(t *testing.T) {
+	d, err := NewCommandDriver("alacritty --title {{.Title}} --working-directory {{.Dir}} -e {{.Command}}")
+	if err != nil {
+		t.Fatalf("NewCommandDriver failed: %v", err)
+	}
+	got, err := d.render("Run Server", "/home/user/my app", []string{"/usr/bin/atelier-go", "sessions", "attach", "--action", "Run Server"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := `alacritty --title 'Run Server' --working-directory '/home/user/my app' -e /usr/bin/atelier-go sessions attach --action 'Run Server'`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
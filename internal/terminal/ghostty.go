@@ -0,0 +1,25 @@
+package terminal
+
+// GhosttyDriver implements Driver by launching new Ghostty windows.
+// Ghostty has no scripting interface on Linux, so panes cannot be split.
+type GhosttyDriver struct{}
+
+// Name returns the driver name.
+func (d *GhosttyDriver) Name() string {
+	return "ghostty"
+}
+
+// OpenTab opens a new Ghostty window.
+func (d *GhosttyDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	args := []string{"--working-directory=" + dir}
+	if title != "" {
+		args = append(args, "--title="+title)
+	}
+	args = append(append(args, "-e"), command...)
+	return "", start(dir, "ghostty", args...)
+}
+
+// Split is not supported by Ghostty.
+func (d *GhosttyDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	return "", ErrUnsupported
+}
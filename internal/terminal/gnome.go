@@ -0,0 +1,26 @@
+package terminal
+
+// GnomeTerminalDriver implements Driver using gnome-terminal tabs.
+// gnome-terminal cannot split panes.
+type GnomeTerminalDriver struct{}
+
+// Name returns the driver name.
+func (d *GnomeTerminalDriver) Name() string {
+	return "gnome-terminal"
+}
+
+// OpenTab opens a new tab in the current gnome-terminal window.
+func (d *GnomeTerminalDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	args := []string{"--tab", "--working-directory=" + dir}
+	if title != "" {
+		args = append(args, "--title="+title)
+	}
+	args = append(append(args, "--"), command...)
+	_, err := output("gnome-terminal", args...)
+	return "", err
+}
+
+// Split is not supported by gnome-terminal.
+func (d *GnomeTerminalDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	return "", ErrUnsupported
+}
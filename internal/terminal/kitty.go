@@ -0,0 +1,31 @@
+package terminal
+
+// KittyDriver implements Driver using kitty remote control.
+// It requires allow_remote_control and, for splits, the splits layout.
+type KittyDriver struct{}
+
+// Name returns the driver name.
+func (d *KittyDriver) Name() string {
+	return "kitty"
+}
+
+// OpenTab opens a new kitty tab and returns the id of its window.
+func (d *KittyDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	args := []string{"@", "launch", "--type=tab", "--cwd", dir}
+	if title != "" {
+		args = append(args, "--tab-title", title)
+	}
+	id, err := output("kitty", append(args, command...)...)
+	return Pane(id), err
+}
+
+// Split opens a new kitty window next to an existing one.
+func (d *KittyDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	location := "vsplit"
+	if direction == Down {
+		location = "hsplit"
+	}
+	args := []string{"@", "launch", "--type=window", "--next-to", "id:" + string(pane), "--location", location, "--cwd", dir}
+	id, err := output("kitty", append(args, command...)...)
+	return Pane(id), err
+}
@@ -0,0 +1,25 @@
+package terminal
+
+// KonsoleDriver implements Driver using Konsole tabs.
+// Konsole cannot open splits from the command line.
+type KonsoleDriver struct{}
+
+// Name returns the driver name.
+func (d *KonsoleDriver) Name() string {
+	return "konsole"
+}
+
+// OpenTab opens a new tab in the running Konsole instance.
+func (d *KonsoleDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	args := []string{"--new-tab", "--workdir", dir}
+	if title != "" {
+		args = append(args, "-p", "tabtitle="+title)
+	}
+	args = append(append(args, "-e"), command...)
+	return "", start(dir, "konsole", args...)
+}
+
+// Split is not supported by Konsole.
+func (d *KonsoleDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	return "", ErrUnsupported
+}
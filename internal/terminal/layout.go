@@ -0,0 +1,88 @@
+package terminal
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PaneCommand returns the command a layout pane runs for an action.
+type PaneCommand func(action string) []string
+
+// Open opens every tab of a layout in dir and returns the number of panes opened.
+// Panes the driver cannot split off are opened in tabs of their own.
+func Open(d Driver, layout config.Layout, dir string, command PaneCommand) (int, error) {
+	opened := 0
+	for _, tab := range layout.Tabs {
+		title := tab.Title
+		if title == "" {
+			title = tab.Panes[0]
+		}
+
+		pane, err := d.OpenTab(title, dir, command(tab.Panes[0]))
+		if err != nil {
+			return opened, fmt.Errorf("failed to open %q: %w", tab.Panes[0], err)
+		}
+		opened++
+
+		direction := Right
+		if tab.Split == string(Down) {
+			direction = Down
+		}
+
+		for _, action := range tab.Panes[1:] {
+			next, err := d.Split(pane, direction, dir, command(action))
+			if errors.Is(err, ErrUnsupported) {
+				next, err = d.OpenTab(action, dir, command(action))
+			}
+			if err != nil {
+				return opened, fmt.Errorf("failed to open %q: %w", action, err)
+			}
+			opened++
+			// Split the newest pane next, so panes line up in order
+			if next != "" {
+				pane = next
+			}
+		}
+	}
+	return opened, nil
+}
+
+// FindLayout returns the location's layout with the given name (case-insensitive).
+func FindLayout(loc locations.Location, name string) (*config.Layout, error) {
+	for _, l := range loc.Layouts {
+		if strings.EqualFold(l.Name, name) {
+			return &l, nil
+		}
+	}
+	return nil, fmt.Errorf("layout %q not found for %q", name, loc.Name)
+}
+
+// OpenLocation opens a location's layout, attaching every pane to its action's
+// persistent session through "atelier-go sessions attach". Panes name the
+// location by its path, so they attach to the same location whatever its name.
+// It returns the driver used and the number of panes opened.
+func OpenLocation(cfg config.Terminal, loc locations.Location, layout config.Layout) (Driver, int, error) {
+	// Actions from project files and discovery are only known now
+	if err := layout.Validate(loc.Actions); err != nil {
+		return nil, 0, err
+	}
+
+	driver, err := NewDriver(cfg)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to locate atelier-go executable: %w", err)
+	}
+
+	n, err := Open(driver, layout, loc.Path, func(action string) []string {
+		return []string{exe, "sessions", "attach", "--folder", loc.Path, "--action", action}
+	})
+	return driver, n, err
+}
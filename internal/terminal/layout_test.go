@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"atelier-go/internal/config"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeDriver records the tabs and splits it is asked to open.
+type fakeDriver struct {
+	splits bool
+	calls  []string
+	next   int
+}
+
+func (d *fakeDriver) Name() string { return "fake" }
+
+func (d *fakeDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	d.next++
+	d.calls = append(d.calls, fmt.Sprintf("tab %s %s", title, strings.Join(command, " ")))
+	return Pane(fmt.Sprint(d.next)), nil
+}
+
+func (d *fakeDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	if !d.splits {
+		return "", ErrUnsupported
+	}
+	d.next++
+	d.calls = append(d.calls, fmt.Sprintf("split %s %s %s", pane, direction, strings.Join(command, " ")))
+	return Pane(fmt.Sprint(d.next)), nil
+}
+
+func TestOpen(t *testing.T) {
+	layout := config.Layout{Name: "dev", Tabs: []config.LayoutTab{
+		{Title: "Code", Panes: []string{"Editor"}},
+		{Split: "down", Panes: []string{"Server", "Tests", "Logs"}},
+	}}
+	command := func(action string) []string { return []string{"attach", action} }
+
+	d := &fakeDriver{splits: true}
+	n, err := Open(d, layout, "/home/user/my-app", command)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	want := []string{
+		"tab Code attach Editor",
+		"tab Server attach Server",
+		"split 2 down attach Tests",
+		"split 3 down attach Logs",
+	}
+	if n != 4 || strings.Join(d.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected calls (%d panes):\n%s", n, strings.Join(d.calls, "\n"))
+	}
+
+	// Without splits every pane gets its own tab
+	d = &fakeDriver{}
+	if n, err := Open(d, layout, "/home/user/my-app", command); err != nil || n != 4 {
+		t.Fatalf("expected 4 panes, got %d (%v)", n, err)
+	}
+	if d.calls[2] != "tab Tests attach Tests" {
+		t.Errorf("expected fallback tab, got %q", d.calls[2])
+	}
+}
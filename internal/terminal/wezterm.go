@@ -0,0 +1,34 @@
+package terminal
+
+// WeztermDriver implements Driver using the WezTerm CLI.
+type WeztermDriver struct{}
+
+// Name returns the driver name.
+func (d *WeztermDriver) Name() string {
+	return "wezterm"
+}
+
+// OpenTab spawns a new WezTerm tab and returns the id of its pane.
+func (d *WeztermDriver) OpenTab(title, dir string, command []string) (Pane, error) {
+	args := append([]string{"cli", "spawn", "--cwd", dir, "--"}, command...)
+	id, err := output("wezterm", args...)
+	if err != nil {
+		return "", err
+	}
+	if title != "" {
+		// The title is cosmetic, so a failure here does not fail the tab
+		_, _ = output("wezterm", "cli", "set-tab-title", "--pane-id", id, title)
+	}
+	return Pane(id), nil
+}
+
+// Split splits an existing WezTerm pane.
+func (d *WeztermDriver) Split(pane Pane, direction Direction, dir string, command []string) (Pane, error) {
+	side := "--right"
+	if direction == Down {
+		side = "--bottom"
+	}
+	args := append([]string{"cli", "split-pane", "--pane-id", string(pane), side, "--cwd", dir, "--"}, command...)
+	id, err := output("wezterm", args...)
+	return Pane(id), err
+}
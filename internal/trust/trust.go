@@ -0,0 +1,100 @@
+// Package trust records which project-local config files the user has
+// approved, so their commands only run once reviewed.
+package trust
+
+import (
+	"atelier-go/internal/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store maps project file paths to the hash of the content that was trusted.
+type Store struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewStore creates a Store kept in the given file.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultStore returns the Store in the atelier-go state directory.
+func DefaultStore() (*Store, error) {
+	dir, err := utils.GetStateSubdir("trust")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trust directory: %w", err)
+	}
+	return NewStore(filepath.Join(dir, "trusted.json")), nil
+}
+
+// IsTrusted reports whether the file at path was trusted with this exact hash.
+func (s *Store) IsTrusted(path, hash string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return false, err
+	}
+	return entries[path] == hash, nil
+}
+
+// Trust records the hash of the file at path as trusted, replacing any
+// previously trusted version.
+func (s *Store) Trust(path, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	entries[path] = hash
+	return s.save(entries)
+}
+
+// Revoke forgets the file at path.
+func (s *Store) Revoke(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(entries, path)
+	return s.save(entries)
+}
+
+func (s *Store) load() (map[string]string, error) {
+	entries := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust store: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse trust store: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *Store) save(entries map[string]string) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode trust store: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write trust store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write trust store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package trust
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "trusted.json"))
+	file := "/home/user/api/.atelier.yaml"
+
+	if ok, err := store.IsTrusted(file, "abc"); err != nil || ok {
+		t.Fatalf("expected an empty store to trust nothing, got %v, %v", ok, err)
+	}
+	if err := store.Trust(file, "abc"); err != nil {
+		t.Fatalf("Trust failed: %v", err)
+	}
+	if ok, _ := store.IsTrusted(file, "abc"); !ok {
+		t.Error("expected the trusted hash to be trusted")
+	}
+	if ok, _ := store.IsTrusted(file, "def"); ok {
+		t.Error("expected a changed file to be untrusted")
+	}
+	if err := store.Revoke(file); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if ok, _ := store.IsTrusted(file, "abc"); ok {
+		t.Error("expected a revoked file to be untrusted")
+	}
+}
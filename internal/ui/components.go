@@ -16,15 +16,26 @@ import (
 // LocationItem wraps locations.Location for list display.
 type LocationItem struct {
 	Location locations.Location
+	Running  bool
 }
 
 // Title returns the formatted name of the location with an icon.
 func (i LocationItem) Title() string {
-	icon := IconFolder
-	if i.Location.Source == "Project" {
-		icon = IconProject
+	return fmt.Sprintf("%s %s", i.Icon(), i.Location.Name)
+}
+
+// Icon returns the icon for the location's source.
+func (i LocationItem) Icon() string {
+	if i.Location.Icon != "" {
+		return i.Location.Icon
+	}
+	switch i.Location.Source {
+	case "Project":
+		return IconProject
+	case "Git":
+		return IconGit
 	}
-	return fmt.Sprintf("%s %s", icon, i.Location.Name)
+	return IconFolder
 }
 
 // Description returns the filesystem path of the location.
@@ -47,10 +58,30 @@ func (i LocationItem) HasActions() bool {
 type ActionItem struct {
 	Action    config.Action
 	IsDefault bool
+	// Instances is the number of live sessions running the action.
+	Instances int
+	// Background is set when the action's session was started without attaching.
+	Background bool
+	// Layout is set when the item opens a layout instead of a single action.
+	Layout *config.Layout
+	// NewWorktree is set on the item that creates a git worktree.
+	NewWorktree bool
+}
+
+// IsAction reports whether the item runs an action, rather than opening a
+// layout or creating a worktree.
+func (a ActionItem) IsAction() bool {
+	return a.Layout == nil && !a.NewWorktree
 }
 
 // Title returns the formatted name of the action.
 func (a ActionItem) Title() string {
+	if a.Layout != nil {
+		return IconLayout + " " + a.Layout.Name
+	}
+	if a.NewWorktree {
+		return IconGit + " New worktree…"
+	}
 	if a.IsDefault {
 		return a.Action.Name + " (Default)"
 	}
@@ -61,7 +92,15 @@ func (a ActionItem) Title() string {
 func (a ActionItem) Description() string { return "" }
 
 // FilterValue returns the string used for filtering actions.
-func (a ActionItem) FilterValue() string { return a.Action.Name }
+func (a ActionItem) FilterValue() string {
+	if a.Layout != nil {
+		return "layout " + a.Layout.Name
+	}
+	if a.NewWorktree {
+		return "new worktree"
+	}
+	return a.Action.Name
+}
 
 // LocationDelegate renders location items with focus-aware styling.
 type LocationDelegate struct {
@@ -95,9 +134,10 @@ func (d LocationDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		return
 	}
 
-	icon := IconFolder
-	if item.IsProject() {
-		icon = IconProject
+	icon := item.Icon()
+	if item.Location.Parent != "" {
+		// Worktrees are listed right after their repository
+		icon = "↳ " + icon
 	}
 
 	var mainPart string
@@ -118,6 +158,10 @@ func (d LocationDelegate) Render(w io.Writer, m list.Model, index int, listItem
 		mainPart = iconStyle.Render(icon) + " " + textStyle.Render(item.Location.Name)
 	}
 
+	if item.Running {
+		mainPart += " " + lipgloss.NewStyle().Foreground(ColorRunning).Render(IconRunning)
+	}
+
 	// Add shortened path if there's enough space
 	shortPath := utils.ShortenPath(item.Location.Path)
 	avail := m.Width() - lipgloss.Width(mainPart) - 2
@@ -186,5 +230,20 @@ func (d ActionDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		style = d.NormalStyle.Foreground(ColorText)
 	}
 
-	_, _ = fmt.Fprint(w, style.Render(item.Title()))
+	line := style.Render(item.Title())
+	if item.Action.Discovered {
+		line += " " + lipgloss.NewStyle().Foreground(ColorSubtext).Render(IconDiscovered)
+	}
+	if item.Instances > 0 {
+		icon := IconRunning
+		if item.Background {
+			icon = IconBackground
+		}
+		line += " " + lipgloss.NewStyle().Foreground(ColorRunning).Render(icon)
+	}
+	if item.Instances > 1 {
+		line += lipgloss.NewStyle().Foreground(ColorSubtext).Render(fmt.Sprintf(" ×%d", item.Instances))
+	}
+
+	_, _ = fmt.Fprint(w, line)
 }
@@ -13,18 +13,25 @@ func (m *Model) syncFilter() []tea.Cmd {
 	var cmds []tea.Cmd
 	val := m.filterInput.Value()
 
-	if m.focus == FocusLocations {
+	switch m.focus {
+	case FocusLocations:
 		if val != m.lastLeftFilter {
 			cmds = append(cmds, m.applyLocationFilter())
 			m.lastLeftFilter = val
 			cmds = append(cmds, m.updateActions())
 		}
-	} else {
+	case FocusActions:
 		if val != m.lastRightFilter {
 			m.actions.SetFilterText(val)
 			m.lastRightFilter = val
 			m.actions.Select(0)
 		}
+	case FocusSessions:
+		if val != m.lastSessionFilter {
+			m.sessionList.SetFilterText(val)
+			m.lastSessionFilter = val
+			m.sessionList.Select(0)
+		}
 	}
 	return cmds
 }
@@ -41,12 +48,12 @@ func (m *Model) applyLocationFilter() tea.Cmd {
 
 	if val == "" {
 		for _, loc := range m.allLocations {
-			items = append(items, LocationItem{Location: loc})
+			items = append(items, m.newLocationItem(loc))
 		}
 	} else {
 		matches := fuzzy.FindFrom(val, locationSource(m.allLocations))
 		for _, match := range matches {
-			items = append(items, LocationItem{Location: m.allLocations[match.Index]})
+			items = append(items, m.newLocationItem(m.allLocations[match.Index]))
 		}
 	}
 
@@ -65,4 +72,4 @@ func (m *Model) applyLocationFilter() tea.Cmd {
 
 	m.locations.Select(0)
 	return m.locations.SetItems(items)
-}
\ No newline at end of file
+}
@@ -13,7 +13,7 @@ func TestApplyLocationFilter(t *testing.T) {
 		{Name: "go-project", Path: "/home/user/go-project", Source: "Project"},
 	}
 
-	m := NewModel(locs)
+	m := NewModel(locs, nil)
 
 	tests := []struct {
 		name     string
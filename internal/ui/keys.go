@@ -1,11 +1,21 @@
 package ui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func (m *Model) handleEscape() []tea.Cmd {
 	var cmds []tea.Cmd
+	if m.focus == FocusSessions {
+		if m.filterInput.Value() != "" {
+			m.filterInput.SetValue("")
+			return nil
+		}
+		return m.toggleSessions()
+	}
+
 	if m.focus == FocusActions {
 		m.setFocus(FocusLocations)
 		m.filterInput.SetValue(m.lastLeftFilter)
@@ -32,6 +42,11 @@ func (m *Model) handleEscape() []tea.Cmd {
 
 func (m *Model) handleSelect() []tea.Cmd {
 	var cmds []tea.Cmd
+	if m.focus == FocusSessions {
+		m.attachSelectedSession()
+		return nil
+	}
+
 	if m.focus == FocusLocations {
 		sel, ok := m.locations.SelectedItem().(LocationItem)
 		if !ok {
@@ -63,24 +78,80 @@ func (m *Model) handleSelect() []tea.Cmd {
 	}
 
 	loc := locItem.Location
-	act := actItem.Action
-	m.Result = SelectionResult{Location: &loc, Action: &act}
-	m.quitting = true
-	return nil
+	if actItem.Layout != nil {
+		if act := m.untrustedPane(loc, *actItem.Layout); act != nil {
+			m.statusMsg = fmt.Sprintf("Run '%s' once to trust %s before opening this layout.", act.Name, act.Origin)
+			return nil
+		}
+		m.Result = SelectionResult{Location: &loc, Layout: actItem.Layout}
+		m.quitting = true
+		return nil
+	}
+	if actItem.NewWorktree {
+		return []tea.Cmd{m.promptWorktree(loc)}
+	}
+	return []tea.Cmd{m.launchAction(loc, actItem.Action, launchAttach)}
 }
 
-func (m *Model) handleFastSelect() {
+func (m *Model) handleFastSelect() tea.Cmd {
+	if m.focus == FocusSessions {
+		m.attachSelectedSession()
+		return nil
+	}
+
 	sel, ok := m.locations.SelectedItem().(LocationItem)
 	if !ok {
-		return
+		return nil
 	}
 
 	loc := sel.Location
+	if act := defaultAction(loc); act != nil && m.needsPrompt(*act) {
+		return m.launchAction(loc, *act, launchAttach)
+	}
 	m.Result = SelectionResult{Location: &loc, Action: nil}
 	m.quitting = true
+	return nil
+}
+
+// handleNewInstance selects the highlighted action (or the location's default)
+// to be started as an additional, independent session.
+func (m *Model) handleNewInstance() tea.Cmd {
+	if m.focus == FocusSessions {
+		return nil
+	}
+
+	sel, ok := m.locations.SelectedItem().(LocationItem)
+	if !ok {
+		return nil
+	}
+	loc := sel.Location
+	m.Result = SelectionResult{Location: &loc, NewInstance: true}
+
+	act := defaultAction(loc)
+	if m.focus == FocusActions {
+		if actItem, ok := m.actions.SelectedItem().(ActionItem); ok {
+			if !actItem.IsAction() {
+				// Layouts open their own sessions
+				m.Result = SelectionResult{}
+				return nil
+			}
+			act = &actItem.Action
+			m.Result.Action = &actItem.Action
+		}
+	}
+	if act != nil && m.needsPrompt(*act) {
+		m.Result = SelectionResult{}
+		return m.launchAction(loc, *act, launchNewInstance)
+	}
+	m.quitting = true
+	return nil
 }
 
 func (m *Model) handleCursorUp() tea.Cmd {
+	if m.focus == FocusSessions {
+		m.sessionList.CursorUp()
+		return nil
+	}
 	if m.focus == FocusLocations {
 		m.locations.CursorUp()
 		return m.updateActions()
@@ -90,6 +161,10 @@ func (m *Model) handleCursorUp() tea.Cmd {
 }
 
 func (m *Model) handleCursorDown() tea.Cmd {
+	if m.focus == FocusSessions {
+		m.sessionList.CursorDown()
+		return nil
+	}
 	if m.focus == FocusLocations {
 		m.locations.CursorDown()
 		return m.updateActions()
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+	"atelier-go/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sessionsMsg carries the result of an asynchronous session listing.
+type sessionsMsg struct {
+	sessions []sessions.Session
+	err      error
+}
+
+// fetchSessions lists live sessions in the background so startup is not blocked.
+func fetchSessions(mgr *sessions.Manager) tea.Cmd {
+	if mgr == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		list, err := mgr.List()
+		return sessionsMsg{sessions: list, err: err}
+	}
+}
+
+// setLiveSessions records the live sessions and refreshes the indicators.
+func (m *Model) setLiveSessions(msg sessionsMsg) tea.Cmd {
+	m.sessionsLoaded = true
+	m.sessionsErr = msg.err
+	m.liveSessions = msg.sessions
+	m.liveNames = make(map[string]bool, len(msg.sessions))
+	m.livePaths = make(map[string]bool, len(msg.sessions))
+	m.liveInstances = make(map[string]int, len(msg.sessions))
+	m.liveBackground = make(map[string]bool, len(msg.sessions))
+	m.liveKeys = make(map[string]bool, len(msg.sessions))
+	m.liveActions = make(map[string]int, len(msg.sessions))
+	m.liveActionsBg = make(map[string]bool, len(msg.sessions))
+	for _, s := range msg.sessions {
+		m.liveNames[s.ID] = true
+		// Sessions started in the background have never been attached
+		m.liveBackground[s.ID] = s.Metadata != nil && s.Metadata.LastAttachedAt.IsZero()
+		if md := s.Metadata; md != nil && md.Key != "" {
+			m.liveKeys[md.Key] = true
+			k := actionKey(md.Key, md.Action)
+			if m.liveActions[k] == 0 {
+				m.liveActionsBg[k] = true
+			}
+			m.liveActions[k]++
+			m.liveActionsBg[k] = m.liveActionsBg[k] && m.liveBackground[s.ID]
+		}
+		base, _ := sessions.SplitInstance(s.ID)
+		m.liveInstances[base]++
+		if s.Path != "" {
+			m.livePaths[s.Path] = true
+		}
+	}
+
+	items := m.locations.Items()
+	for i, it := range items {
+		if li, ok := it.(LocationItem); ok {
+			items[i] = m.newLocationItem(li.Location)
+		}
+	}
+	return tea.Batch(m.locations.SetItems(items), m.updateActions(), m.setSessionItems())
+}
+
+// newLocationItem wraps a location, marking it when it has a live session.
+func (m *Model) newLocationItem(loc locations.Location) LocationItem {
+	return LocationItem{Location: loc, Running: m.isLocationRunning(loc)}
+}
+
+// isLocationRunning reports whether any live session belongs to the location.
+func (m *Model) isLocationRunning(loc locations.Location) bool {
+	if len(m.liveNames) == 0 {
+		return false
+	}
+	if m.livePaths[loc.Path] || m.liveKeys[m.sessionManager.ProjectKey(loc)] {
+		return true
+	}
+	for _, name := range m.sessionManager.TargetNames(loc) {
+		if m.liveInstances[name] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// actionStatus counts the live instances of the action's session and reports
+// whether it is running in the background without having been attached.
+// Actions with params run one session per set of values, which are found
+// through their metadata rather than by name.
+func (m *Model) actionStatus(loc locations.Location, act config.Action) (int, bool) {
+	if len(m.liveNames) == 0 {
+		return 0, false
+	}
+	if len(act.Params) > 0 {
+		k := actionKey(m.sessionManager.ProjectKey(loc), act.Name)
+		return m.liveActions[k], m.liveActionsBg[k]
+	}
+	name := m.sessionManager.SessionName(loc, act.Name)
+	return m.liveInstances[name], m.liveBackground[name]
+}
+
+// actionKey identifies an action of a location in session metadata.
+func actionKey(projectKey, action string) string {
+	return projectKey + "\x00" + utils.Sanitize(action)
+}
@@ -3,6 +3,8 @@ package ui
 import (
 	"atelier-go/internal/config"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+	"atelier-go/internal/trust"
 	"sort"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -19,41 +21,84 @@ const (
 	FocusLocations Focus = iota
 	// FocusActions represents the right panel (available commands).
 	FocusActions
+	// FocusSessions represents the live sessions panel.
+	FocusSessions
 )
 
 // SelectionResult holds the final user selection.
 type SelectionResult struct {
 	Location *locations.Location
 	Action   *config.Action
-	Canceled bool
+	// Target is set when an existing or restarted session was chosen directly.
+	Target *sessions.Target
+	// Layout is set when a layout was chosen; its panes open in new terminal tabs.
+	Layout *config.Layout
+	// Params holds the values entered for the action's params.
+	Params map[string]string
+	// NewInstance starts another instance of the selection instead of attaching.
+	NewInstance bool
+	Canceled    bool
 }
 
 // Model is the Bubble Tea model for the TUI.
 type Model struct {
 	// Data
-	allLocations []locations.Location
+	allLocations   []locations.Location
+	sessionManager *sessions.Manager
+	liveSessions   []sessions.Session
+	liveNames      map[string]bool
+	livePaths      map[string]bool
+	liveInstances  map[string]int
+	liveBackground map[string]bool
+	liveKeys       map[string]bool
+	liveActions    map[string]int  // project key and action -> live sessions
+	liveActionsBg  map[string]bool // project key and action -> only started in the background
+	editor         string
+	sessionsLoaded bool
+	sessionsErr    error
 
 	// Components
 	locations         list.Model
 	locationsDelegate LocationDelegate
 	actions           list.Model
 	actionsDelegate   ActionDelegate
+	sessionList       list.Model
+	sessionsDelegate  SessionDelegate
 	filterInput       textinput.Model
+	promptInput       textinput.Model
 
 	// State
-	focus           Focus
-	layout          Layout
-	styles          Styles
-	quitting        bool
-	lastLeftFilter  string
-	lastRightFilter string
+	focus             Focus
+	layout            Layout
+	styles            Styles
+	quitting          bool
+	lastLeftFilter    string
+	lastRightFilter   string
+	lastSessionFilter string
+	pending           pendingOp
+	pendingSession    string
+	params            *paramPrompt
+	worktreeFrom      string
+	trust             *trust.Store
+	statusMsg         string
+	warnings          []locations.ProviderError
+	showPreview       bool
+	previewPercent    int
+	previewKey        string
+	previewCache      map[string]previewEntry
 
 	// Result
 	Result SelectionResult
 }
 
 // NewModel creates a TUI model from locations.
-func NewModel(locs []locations.Location) *Model {
+// The session manager, if not nil, is used to show which locations have live sessions.
+func NewModel(locs []locations.Location, sessionManager *sessions.Manager) *Model {
+	if sessionManager != nil {
+		// Locations sharing a name must be told apart in their session names
+		sessionManager.SetLocations(locs)
+	}
+
 	// Convert to list items
 	items := make([]list.Item, len(locs))
 	for i, loc := range locs {
@@ -96,6 +141,22 @@ func NewModel(locs []locations.Location) *Model {
 	actList.SetShowStatusBar(false)
 	actList.SetShowHelp(false)
 
+	// Session list (filled once sessions are fetched)
+	sessDelegate := NewSessionDelegate(styles)
+	sessList := list.New(nil, sessDelegate, layout.LeftWidth, layout.ListHeight)
+	sessList.SetShowTitle(false)
+	sessList.SetShowFilter(false)
+	sessList.SetShowStatusBar(false)
+	sessList.SetShowHelp(false)
+
+	// Rename prompt
+	pi := textinput.New()
+	pi.Prompt = "Rename to: "
+	pi.CharLimit = 64
+	pi.Width = layout.ContentWidth - 16
+	pi.PromptStyle = lipgloss.NewStyle().Foreground(ColorHighlight).Bold(true)
+	pi.TextStyle = lipgloss.NewStyle().Foreground(ColorText).Bold(true)
+
 	// Filter input
 	ti := textinput.New()
 	ti.Placeholder = "Search..."
@@ -109,11 +170,17 @@ func NewModel(locs []locations.Location) *Model {
 
 	return &Model{
 		allLocations:      locs,
+		sessionManager:    sessionManager,
 		locations:         locList,
 		locationsDelegate: locDelegate,
 		actions:           actList,
 		actionsDelegate:   actDelegate,
+		sessionList:       sessList,
+		sessionsDelegate:  sessDelegate,
 		filterInput:       ti,
+		promptInput:       pi,
+		previewPercent:    defaultPreviewPercent,
+		previewCache:      make(map[string]previewEntry),
 		focus:             FocusLocations,
 		layout:            layout,
 		styles:            styles,
@@ -122,7 +189,7 @@ func NewModel(locs []locations.Location) *Model {
 
 // Init initializes the model.
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(textinput.Blink, m.updateActions())
+	return tea.Batch(textinput.Blink, m.updateActions(), fetchSessions(m.sessionManager))
 }
 
 // Update handles terminal messages and user input.
@@ -131,6 +198,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// 1. Handle key messages
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		// A pending session operation consumes all input until resolved
+		if m.pending != pendingNone {
+			return m, m.handlePendingKey(keyMsg)
+		}
+
 		switch keyMsg.String() {
 		case "ctrl+c":
 			m.Result = SelectionResult{Canceled: true}
@@ -150,7 +222,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "alt+enter", "ctrl+s":
-			m.handleFastSelect()
+			cmds = append(cmds, m.handleFastSelect())
 			if m.quitting {
 				return m, tea.Quit
 			}
@@ -160,15 +232,71 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "down", "ctrl+n":
 			cmds = append(cmds, m.handleCursorDown())
+
+		case "ctrl+t":
+			cmds = append(cmds, m.handleNewInstance())
+			if m.quitting {
+				return m, tea.Quit
+			}
+
+		case "ctrl+b":
+			// ctrl+b would also move the filter input's cursor
+			return m, m.startInBackground()
+
+		case "ctrl+o":
+			cmds = append(cmds, m.toggleSessions()...)
+
+		// The filter input binds ctrl+v to paste and types alt-modified runes,
+		// so these keys must not reach it
+		case "ctrl+v":
+			return m, m.togglePreview()
+
+		case "alt+h":
+			m.resizePreview(previewStep)
+			return m, nil
+
+		case "alt+l":
+			m.resizePreview(-previewStep)
+			return m, nil
+
+		case "ctrl+x":
+			if m.focus == FocusSessions {
+				return m, m.handleSessionOp(pendingKill)
+			}
+
+		case "ctrl+r":
+			if m.focus == FocusSessions {
+				return m, m.handleSessionOp(pendingRestart)
+			}
+
+		case "ctrl+e":
+			if m.focus == FocusSessions {
+				return m, m.handleSessionOp(pendingRename)
+			}
 		}
 	}
 
 	// 2. Handle other messages
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		m.layout = DefaultLayout(msg.Width, msg.Height)
-		m.styles = DefaultStyles(m.layout)
-		m.updateDimensions()
+		m.layout.Width, m.layout.Height = msg.Width, msg.Height
+		m.relayout()
+	case sessionsMsg:
+		cmds = append(cmds, m.setLiveSessions(msg))
+	case sessionOpMsg:
+		cmds = append(cmds, m.handleSessionOpResult(msg))
+	case previewMsg:
+		m.previewCache[msg.key] = msg.entry
+	case worktreeMsg:
+		cmds = append(cmds, m.handleWorktreeResult(msg))
+		if m.quitting {
+			return m, tea.Batch(cmds...)
+		}
+	case restartMsg:
+		cmds = append(cmds, m.handleRestartResult(msg))
+		if m.quitting {
+			return m, tea.Batch(cmds...)
+		}
 	}
 
 	// 3. Update components (always update filter input if not quitting)
@@ -186,6 +314,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 			m.actions, cmd = m.actions.Update(msg)
 			cmds = append(cmds, cmd)
+			m.sessionList, cmd = m.sessionList.Update(msg)
+			cmds = append(cmds, cmd)
 		}
 	}
 
@@ -201,10 +331,13 @@ func (m *Model) setFocus(f Focus) {
 	m.actions.SetDelegate(m.actionsDelegate)
 
 	// Update search prompt based on focus
-	if f == FocusLocations {
+	switch f {
+	case FocusLocations:
 		m.filterInput.Prompt = IconSearch + " "
-	} else {
+	case FocusActions:
 		m.filterInput.Prompt = "Action " + IconSearch + " "
+	case FocusSessions:
+		m.filterInput.Prompt = "Session " + IconSearch + " "
 	}
 }
 
@@ -213,16 +346,26 @@ func (m *Model) updateActions() tea.Cmd {
 
 	if sel, ok := m.locations.SelectedItem().(LocationItem); ok {
 		for i, act := range sel.Location.Actions {
+			instances, background := m.actionStatus(sel.Location, act)
 			items = append(items, ActionItem{
-				Action:    act,
-				IsDefault: i == 0,
+				Action:     act,
+				IsDefault:  i == 0,
+				Instances:  instances,
+				Background: background,
 			})
 		}
+		for _, l := range sel.Location.Layouts {
+			items = append(items, ActionItem{Layout: &l})
+		}
+		if sel.Location.Repo != "" {
+			items = append(items, ActionItem{NewWorktree: true})
+		}
 	}
 
+	// The selected location may have changed, so refresh the preview too
 	if len(items) == 0 {
-		return m.actions.SetItems(nil)
+		return tea.Batch(m.actions.SetItems(nil), m.requestPreview())
 	}
 
-	return m.actions.SetItems(items)
+	return tea.Batch(m.actions.SetItems(items), m.requestPreview())
 }
@@ -1,8 +1,16 @@
 package ui
 
 import (
+	"atelier-go/internal/config"
 	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+	"atelier-go/internal/trust"
+	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestNewModel_InitialSorting(t *testing.T) {
@@ -13,7 +21,7 @@ func TestNewModel_InitialSorting(t *testing.T) {
 		{Name: "dotfiles", Path: "/home/user/dotfiles", Source: "Zoxide"},
 	}
 
-	m := NewModel(locs)
+	m := NewModel(locs, nil)
 
 	items := m.locations.Items()
 	if len(items) != 3 {
@@ -22,7 +30,7 @@ func TestNewModel_InitialSorting(t *testing.T) {
 
 	// Expect: Project first
 	expected := []string{"atelier", "work", "dotfiles"}
-	
+
 	// Check items against expected (assuming stable sort of original zoxide items)
 	for i, name := range expected {
 		item := items[i].(LocationItem)
@@ -32,3 +40,230 @@ func TestNewModel_InitialSorting(t *testing.T) {
 	}
 }
 
+func TestSetLiveSessions(t *testing.T) {
+	locs := []locations.Location{
+		{Name: "My App", Path: "/home/user/my-app", Source: "Project", Actions: []config.Action{
+			{Name: "Run Server", Command: "npm start"},
+			{Name: "Shell"},
+		}},
+		{Name: "dotfiles", Path: "/home/user/dotfiles", Source: "Zoxide"},
+		{Name: "notes", Path: "/home/user/notes", Source: "Zoxide"},
+	}
+
+	m := NewModel(locs, nil)
+	m.setLiveSessions(sessionsMsg{sessions: []sessions.Session{
+		{ID: "my-app:run-server"},
+		{ID: "my-app:run-server#2"},
+		{ID: "my-app#2", Metadata: &sessions.Metadata{Action: "Shell"}},
+		{ID: "scratch", Path: "/home/user/notes"},
+	}})
+
+	running := map[string]bool{}
+	for _, it := range m.locations.Items() {
+		item := it.(LocationItem)
+		running[item.Location.Name] = item.Running
+	}
+	expected := map[string]bool{"My App": true, "dotfiles": false, "notes": true}
+	for name, want := range expected {
+		if running[name] != want {
+			t.Errorf("location %s: expected running=%v, got %v", name, want, running[name])
+		}
+	}
+
+	// The first location is selected, so its actions are listed
+	actions := m.actions.Items()
+	if len(actions) != 2 {
+		t.Fatalf("expected 2 actions, got %d", len(actions))
+	}
+	if n := actions[0].(ActionItem).Instances; n != 2 {
+		t.Errorf("expected Run Server to have 2 instances, got %d", n)
+	}
+	// An extra instance counts even when the first one is gone
+	if n := actions[1].(ActionItem).Instances; n != 1 {
+		t.Errorf("expected Shell to have 1 instance, got %d", n)
+	}
+	if actions[0].(ActionItem).Background {
+		t.Error("expected Run Server not to be marked as a background session")
+	}
+}
+
+func TestSessionsPanel(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.setLiveSessions(sessionsMsg{sessions: []sessions.Session{
+		{ID: "my-app", Path: "/home/user/my-app", Backend: "zmx"},
+		{ID: "my-app:run-server", Path: "/home/user/my-app", Backend: "tmux"},
+	}})
+	m.setFocus(FocusSessions)
+	m.handleCursorDown()
+
+	// Declining the confirmation leaves the session alone
+	m.handleSessionOp(pendingKill)
+	if m.pending != pendingKill || m.pendingSession != "my-app:run-server" {
+		t.Fatalf("expected pending kill of my-app:run-server, got %v %q", m.pending, m.pendingSession)
+	}
+	if cmd := m.handlePendingKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); cmd != nil {
+		t.Error("expected no command after declining")
+	}
+	if m.pending != pendingNone {
+		t.Errorf("expected pending operation to be cleared, got %v", m.pending)
+	}
+
+	m.handleSelect()
+	if !m.quitting || m.Result.Target == nil {
+		t.Fatal("expected selecting a session to quit with a target")
+	}
+	if m.Result.Target.Name != "my-app:run-server" || m.Result.Target.Backend != "tmux" {
+		t.Errorf("unexpected target: %+v", m.Result.Target)
+	}
+}
+
+func TestParamPrompt(t *testing.T) {
+	locs := []locations.Location{
+		{Name: "api", Path: "/home/user/api", Source: "Project", Actions: []config.Action{
+			{Name: "Test file", Command: "go test ./{{.Params.pkg}}/...", Params: []config.Param{
+				{Name: "pkg", Prompt: "Package"},
+				{Name: "mode", Default: "short", Choices: []string{"short", "race"}},
+			}},
+		}},
+	}
+
+	m := NewModel(locs, nil)
+	m.handleSelect()
+	m.handleSelect()
+	if m.quitting || m.pending != pendingParams {
+		t.Fatalf("expected a parameter prompt, got pending=%v quitting=%v", m.pending, m.quitting)
+	}
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	// A param without a default needs a value
+	m.handlePendingKey(enter)
+	if m.params == nil || m.params.index != 0 {
+		t.Fatal("expected an empty value to be rejected")
+	}
+	m.promptInput.SetValue("internal/ui")
+	m.handlePendingKey(enter)
+
+	// Tab cycles through choices, starting from the default
+	m.handlePendingKey(tea.KeyMsg{Type: tea.KeyTab})
+	if got := m.promptInput.Value(); got != "race" {
+		t.Errorf("expected tab to pick the next choice, got %q", got)
+	}
+	m.handlePendingKey(enter)
+
+	if !m.quitting || m.Result.Action == nil || m.Result.Action.Name != "Test file" {
+		t.Fatalf("expected the action to be selected, got %+v", m.Result)
+	}
+	if m.Result.Params["pkg"] != "internal/ui" || m.Result.Params["mode"] != "race" {
+		t.Errorf("unexpected params: %v", m.Result.Params)
+	}
+}
+
+func TestTrustPrompt(t *testing.T) {
+	locs := []locations.Location{
+		{Name: "api", Path: "/home/user/api", Source: "Project", Actions: []config.Action{
+			{Name: "Serve", Command: "make serve", Origin: "/home/user/api/.atelier.yaml", OriginHash: "abc"},
+		}},
+	}
+
+	store := trust.NewStore(filepath.Join(t.TempDir(), "trusted.json"))
+	m := NewModel(locs, nil)
+	m.trust = store
+	m.handleFastSelect()
+	if m.quitting || m.pending != pendingTrust {
+		t.Fatalf("expected a trust prompt, got pending=%v quitting=%v", m.pending, m.quitting)
+	}
+
+	// Declining leaves the picker open
+	m.handlePendingKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.quitting || m.pending != pendingNone {
+		t.Fatalf("expected declining to cancel, got pending=%v quitting=%v", m.pending, m.quitting)
+	}
+
+	m.handleFastSelect()
+	m.handlePendingKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !m.quitting || m.Result.Action == nil || m.Result.Action.Name != "Serve" {
+		t.Fatalf("expected the action to be selected, got %+v", m.Result)
+	}
+	if ok, _ := store.IsTrusted("/home/user/api/.atelier.yaml", "abc"); !ok {
+		t.Error("expected the file to be trusted")
+	}
+
+	// Once trusted, the action runs without asking
+	m = NewModel(locs, nil)
+	m.trust = store
+	m.handleFastSelect()
+	if !m.quitting || m.pending != pendingNone {
+		t.Errorf("expected a trusted action to run directly, got pending=%v quitting=%v", m.pending, m.quitting)
+	}
+}
+
+func TestStatusLineShowsProviderWarnings(t *testing.T) {
+	locs := []locations.Location{{Name: "api", Path: "/home/user/api", Source: "Project"}}
+	m := NewModel(locs, nil)
+	m.warnings = []locations.ProviderError{{Provider: "Zoxide", Err: errors.New("zoxide not installed")}}
+
+	if line := m.statusLine(); !strings.Contains(line, "Zoxide: zoxide not installed") {
+		t.Errorf("expected the warning in the status line, got %q", line)
+	}
+	m.statusMsg = "Started 'api:shell' in the background."
+	if line := m.statusLine(); !strings.Contains(line, "Started") || !strings.Contains(line, "Zoxide") {
+		t.Errorf("expected the status message and the warning, got %q", line)
+	}
+
+	// Warnings stay visible whatever the state of the sessions
+	m.statusMsg = ""
+	m.sessionManager = &sessions.Manager{}
+	if line := m.statusLine(); !strings.Contains(line, "Loading sessions") || !strings.Contains(line, "Zoxide") {
+		t.Errorf("expected the warning while sessions load, got %q", line)
+	}
+	m.sessionsLoaded, m.sessionsErr = true, errors.New("zmx not found")
+	if line := m.statusLine(); !strings.Contains(line, "Sessions unavailable") || !strings.Contains(line, "Zoxide") {
+		t.Errorf("expected the warning when sessions are unavailable, got %q", line)
+	}
+}
+
+func TestNewWorktreeAsksToTrustDefaultAction(t *testing.T) {
+	wt := locations.Location{Name: "api/feature", Path: "/home/user/api-feature", Source: "Project", Actions: []config.Action{
+		{Name: "Serve", Command: "make serve", Origin: "/home/user/api-feature/.atelier.yaml", OriginHash: "abc"},
+	}}
+
+	m := NewModel(nil, nil)
+	m.trust = trust.NewStore(filepath.Join(t.TempDir(), "trusted.json"))
+	m.handleWorktreeResult(worktreeMsg{location: wt})
+	if m.quitting || m.pending != pendingTrust {
+		t.Fatalf("expected a trust prompt before opening the worktree, got pending=%v quitting=%v", m.pending, m.quitting)
+	}
+}
+
+func TestActionStatusWithParams(t *testing.T) {
+	locs := []locations.Location{
+		{Name: "api", Path: "/home/user/api", Source: "Project", Actions: []config.Action{
+			{Name: "Test file", Command: "go test ./{{.Params.pkg}}/... # {{.GitBranch}}", Params: []config.Param{{Name: "pkg"}}},
+		}},
+	}
+
+	m := NewModel(locs, nil)
+	m.setLiveSessions(sessionsMsg{sessions: []sessions.Session{
+		{ID: "api:test-file-1a2b3c", Metadata: &sessions.Metadata{Key: "api", Action: "Test file"}},
+		{ID: "api:test-file-4d5e6f", Metadata: &sessions.Metadata{Key: "api", Action: "Test file"}},
+	}})
+
+	item := m.locations.Items()[0].(LocationItem)
+	if !item.Running {
+		t.Error("expected the location to be running")
+	}
+	if n := m.actions.Items()[0].(ActionItem).Instances; n != 2 {
+		t.Errorf("expected one instance per param set, got %d", n)
+	}
+}
+
+func TestBackgroundKeySkipsFilter(t *testing.T) {
+	m := NewModel([]locations.Location{{Name: "api", Path: "/home/user/api", Source: "Project"}}, nil)
+	m.filterInput.SetValue("api")
+	m.filterInput.CursorEnd()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlB})
+	if pos := m.filterInput.Position(); pos != 3 {
+		t.Errorf("expected the filter cursor to stay at the end, got %d", pos)
+	}
+}
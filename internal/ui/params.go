@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launchMode is what happens once an action's params have been entered.
+type launchMode int
+
+const (
+	launchAttach launchMode = iota
+	launchNewInstance
+	launchBackground
+)
+
+// paramPrompt asks for an action's params one at a time.
+type paramPrompt struct {
+	location locations.Location
+	action   config.Action
+	mode     launchMode
+	index    int
+	values   map[string]string
+}
+
+// current returns the param being asked for.
+func (p *paramPrompt) current() config.Param {
+	return p.action.Params[p.index]
+}
+
+// defaultAction returns the location's default action, or nil when it only
+// has the built-in shell.
+func defaultAction(loc locations.Location) *config.Action {
+	if len(loc.Actions) == 0 {
+		return nil
+	}
+	return &loc.Actions[0]
+}
+
+// launchAction runs the chosen action, first asking to trust the project file
+// it comes from if needed, then prompting for its params if it declares any.
+func (m *Model) launchAction(loc locations.Location, act config.Action, mode launchMode) tea.Cmd {
+	if !m.isTrusted(act) {
+		return m.promptTrust(loc, act, mode)
+	}
+	if len(act.Params) == 0 {
+		return m.finishLaunch(loc, act, nil, mode)
+	}
+
+	m.params = &paramPrompt{location: loc, action: act, mode: mode, values: make(map[string]string)}
+	m.pending = pendingParams
+	m.statusMsg = ""
+	return m.showParam()
+}
+
+// showParam prepares the prompt for the current param.
+func (m *Model) showParam() tea.Cmd {
+	p := m.params.current()
+	m.promptInput.Prompt = p.Label() + ": "
+	m.promptInput.SetValue(p.Default)
+	m.promptInput.CursorEnd()
+	return m.promptInput.Focus()
+}
+
+// handleParamKey processes input while prompting for params.
+// Tab cycles through the param's choices.
+func (m *Model) handleParamKey(msg tea.KeyMsg) tea.Cmd {
+	prompt := m.params
+	p := prompt.current()
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.clearPending()
+		return nil
+	case "tab":
+		if len(p.Choices) > 0 {
+			next := (slices.Index(p.Choices, m.promptInput.Value()) + 1) % len(p.Choices)
+			m.promptInput.SetValue(p.Choices[next])
+			m.promptInput.CursorEnd()
+		}
+		return nil
+	case "enter":
+		value := strings.TrimSpace(m.promptInput.Value())
+		if len(p.Choices) > 0 && !slices.Contains(p.Choices, value) {
+			m.statusMsg = fmt.Sprintf("%s must be one of %s", p.Label(), strings.Join(p.Choices, ", "))
+			return nil
+		}
+		if value == "" {
+			m.statusMsg = p.Label() + " is required"
+			return nil
+		}
+		m.statusMsg = ""
+		prompt.values[p.Name] = value
+		prompt.index++
+		if prompt.index < len(prompt.action.Params) {
+			return m.showParam()
+		}
+		m.clearPending()
+		return m.finishLaunch(prompt.location, prompt.action, prompt.values, prompt.mode)
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return cmd
+}
+
+// paramHint describes the current param's choices.
+func (m *Model) paramHint() string {
+	p := m.params.current()
+	hint := fmt.Sprintf("Parameter %d of %d for %s", m.params.index+1, len(m.params.action.Params), m.params.action.Name)
+	if len(p.Choices) > 0 {
+		hint += " • Tab: " + strings.Join(p.Choices, ", ")
+	}
+	return hint + " • Esc:Cancel"
+}
+
+// finishLaunch selects the action or starts it in the background.
+func (m *Model) finishLaunch(loc locations.Location, act config.Action, params map[string]string, mode launchMode) tea.Cmd {
+	if mode == launchBackground {
+		return m.startAction(loc, act.Name, params)
+	}
+	m.Result = SelectionResult{Location: &loc, Action: &act, Params: params, NewInstance: mode == launchNewInstance}
+	m.quitting = true
+	return tea.Quit
+}
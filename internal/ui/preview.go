@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// defaultPreviewPercent is the initial share of the content width for the preview pane.
+	defaultPreviewPercent = 40
+	minPreviewPercent     = 20
+	maxPreviewPercent     = 70
+	previewStep           = 5
+
+	// sessionPreviewTTL is how long captured session output is reused before refetching.
+	sessionPreviewTTL = 2 * time.Second
+)
+
+// readmeNames are checked in order when previewing a directory.
+var readmeNames = []string{"README.md", "README", "README.txt", "readme.md", "Readme.md"}
+
+// previewEntry is a cached preview.
+type previewEntry struct {
+	content   string
+	fetchedAt time.Time
+}
+
+// previewMsg carries a preview rendered in the background.
+type previewMsg struct {
+	key   string
+	entry previewEntry
+}
+
+// togglePreview shows or hides the preview pane.
+func (m *Model) togglePreview() tea.Cmd {
+	m.showPreview = !m.showPreview
+	m.relayout()
+	return m.requestPreview()
+}
+
+// resizePreview grows or shrinks the preview pane by delta percent.
+func (m *Model) resizePreview(delta int) {
+	if !m.showPreview {
+		return
+	}
+	m.previewPercent = max(minPreviewPercent, min(maxPreviewPercent, m.previewPercent+delta))
+	m.relayout()
+}
+
+// relayout recomputes the layout for the current terminal size and preview state.
+func (m *Model) relayout() {
+	percent := 0
+	if m.showPreview {
+		percent = m.previewPercent
+	}
+	m.layout = NewLayout(m.layout.Width, m.layout.Height, percent)
+	m.styles = DefaultStyles(m.layout)
+	m.updateDimensions()
+}
+
+// requestPreview loads the preview for the selected location unless a fresh
+// copy is cached.
+func (m *Model) requestPreview() tea.Cmd {
+	if !m.showPreview {
+		return nil
+	}
+	sel, ok := m.locations.SelectedItem().(LocationItem)
+	if !ok {
+		m.previewKey = ""
+		return nil
+	}
+
+	lines := m.layout.ListHeight + 2
+	if name := m.previewSession(sel.Location); name != "" && m.sessionManager != nil {
+		key := "session:" + name
+		m.previewKey = key
+		if e, ok := m.previewCache[key]; ok && time.Since(e.fetchedAt) < sessionPreviewTTL {
+			return nil
+		}
+		mgr, loc := m.sessionManager, sel.Location
+		return func() tea.Msg {
+			return previewMsg{key: key, entry: sessionPreview(mgr, name, loc, lines)}
+		}
+	}
+
+	key := "dir:" + sel.Location.Path
+	m.previewKey = key
+	if _, ok := m.previewCache[key]; ok {
+		return nil
+	}
+	path := sel.Location.Path
+	return func() tea.Msg {
+		return previewMsg{key: key, entry: previewEntry{content: directoryPreview(path, lines), fetchedAt: time.Now()}}
+	}
+}
+
+// previewSession picks the live session to preview for a location, preferring
+// the session of its default action.
+func (m *Model) previewSession(loc locations.Location) string {
+	if len(m.liveNames) == 0 {
+		return ""
+	}
+	defaultName := "Shell"
+	if act := defaultAction(loc); act != nil {
+		defaultName = act.Name
+	}
+	if name := m.sessionManager.SessionName(loc, defaultName); m.liveNames[name] {
+		return name
+	}
+	for _, name := range m.sessionManager.TargetNames(loc) {
+		if m.liveNames[name] {
+			return name
+		}
+	}
+	for _, s := range m.liveSessions {
+		if s.Path == loc.Path {
+			return s.ID
+		}
+	}
+	return ""
+}
+
+// sessionPreview captures the session's recent output, falling back to a
+// directory preview when the backend cannot capture.
+func sessionPreview(mgr *sessions.Manager, name string, loc locations.Location, lines int) previewEntry {
+	out, err := mgr.Capture(name, lines)
+	switch {
+	case errors.Is(err, sessions.ErrUnsupported):
+		out = directoryPreview(loc.Path, lines)
+	case err != nil:
+		out = "Preview unavailable: " + err.Error()
+	default:
+		out = ansi.Strip(out)
+	}
+	header := fmt.Sprintf("%s %s", IconRunning, name)
+	return previewEntry{content: header + "\n\n" + out, fetchedAt: time.Now()}
+}
+
+// directoryPreview lists the directory's entries followed by the head of its README.
+func directoryPreview(path string, maxLines int) string {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "Preview unavailable: " + err.Error()
+	}
+
+	var dirs, files []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if e.IsDir() {
+			dirs = append(dirs, e.Name()+"/")
+		} else {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(dirs)
+	sort.Strings(files)
+	names := append(dirs, files...)
+
+	readme := readmeHead(path)
+
+	// Give the listing half the space when there is a README to show
+	listBudget := maxLines
+	if readme != "" {
+		listBudget = maxLines / 2
+	}
+
+	var lines []string
+	if len(names) == 0 {
+		lines = append(lines, "(empty directory)")
+	}
+	for i, n := range names {
+		if i == listBudget-1 && len(names) > listBudget {
+			lines = append(lines, fmt.Sprintf("… %d more", len(names)-i))
+			break
+		}
+		lines = append(lines, n)
+	}
+
+	if readme != "" {
+		lines = append(lines, "", readme)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// readmeHead returns the first lines of the directory's README, if any.
+func readmeHead(dir string) string {
+	for _, name := range readmeNames {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		head := strings.SplitN(string(content), "\n", 21)
+		if len(head) > 20 {
+			head = head[:20]
+		}
+		return "── " + name + " ──\n" + strings.TrimRight(strings.Join(head, "\n"), "\n")
+	}
+	return ""
+}
+
+// previewView renders the preview pane for the current selection.
+func (m *Model) previewView() string {
+	title := m.styles.NormalTitle.Render("PREVIEW")
+
+	body := "Loading preview..."
+	if e, ok := m.previewCache[m.previewKey]; ok {
+		body = e.content
+	} else if m.previewKey == "" {
+		body = "Nothing selected"
+	}
+
+	width := m.layout.PreviewWidth - 1
+	lines := strings.Split(body, "\n")
+	for i, l := range lines {
+		lines[i] = truncate(strings.ReplaceAll(l, "\t", "    "), width)
+	}
+
+	return m.styles.PreviewPanel.Render(
+		lipgloss.JoinVertical(lipgloss.Left, title, "", strings.Join(lines, "\n")),
+	)
+}
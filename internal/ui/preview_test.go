@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDirectoryPreview(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"src", ".git"} {
+		if err := os.Mkdir(filepath.Join(dir, d), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Title\n\nHello\n"), 0644); err != nil {
+		t.Fatalf("failed to write readme: %v", err)
+	}
+
+	got := directoryPreview(dir, 10)
+	expected := "src/\nREADME.md\ngo.mod\n\n── README.md ──\n# Title\n\nHello"
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestDirectoryPreview_Truncates(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a", "b", "c", "d", "e"} {
+		if err := os.WriteFile(filepath.Join(dir, f), nil, 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	got := strings.Split(directoryPreview(dir, 3), "\n")
+	expected := []string{"a", "b", "… 3 more"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestNewLayout_Preview(t *testing.T) {
+	plain := NewLayout(120, 40, 0)
+	if plain.PreviewWidth != 0 {
+		t.Errorf("expected no preview width, got %d", plain.PreviewWidth)
+	}
+
+	withPreview := NewLayout(200, 40, 40)
+	if withPreview.PreviewWidth != withPreview.ContentWidth*40/100 {
+		t.Errorf("expected preview to take 40%% of %d, got %d", withPreview.ContentWidth, withPreview.PreviewWidth)
+	}
+	total := withPreview.LeftWidth + withPreview.RightWidth + withPreview.PreviewWidth
+	if total > withPreview.ContentWidth {
+		t.Errorf("panels (%d) exceed content width (%d)", total, withPreview.ContentWidth)
+	}
+}
+
+func TestResizePreview_Clamps(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.togglePreview()
+	for range 20 {
+		m.resizePreview(previewStep)
+	}
+	if m.previewPercent != maxPreviewPercent {
+		t.Errorf("expected preview clamped to %d, got %d", maxPreviewPercent, m.previewPercent)
+	}
+	if m.layout.PreviewWidth == 0 {
+		t.Error("expected preview pane in layout")
+	}
+
+	m.togglePreview()
+	if m.layout.PreviewWidth != 0 {
+		t.Error("expected preview pane hidden")
+	}
+}
+
+func TestPreviewKeysSkipFilter(t *testing.T) {
+	m := NewModel(nil, nil)
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlV},
+		{Type: tea.KeyRunes, Runes: []rune("h"), Alt: true},
+		{Type: tea.KeyRunes, Runes: []rune("l"), Alt: true},
+	} {
+		m.Update(msg)
+	}
+	if !m.showPreview {
+		t.Error("expected ctrl+v to show the preview")
+	}
+	if m.previewPercent != defaultPreviewPercent {
+		t.Errorf("expected the resize to be undone, got %d%%", m.previewPercent)
+	}
+	if v := m.filterInput.Value(); v != "" {
+		t.Errorf("expected the filter to stay empty, got %q", v)
+	}
+}
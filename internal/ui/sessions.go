@@ -0,0 +1,375 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"atelier-go/internal/env"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/sessions"
+	"atelier-go/internal/utils"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SessionItem wraps sessions.Session for list display.
+type SessionItem struct {
+	Session sessions.Session
+}
+
+// Title returns the session name.
+func (i SessionItem) Title() string { return i.Session.ID }
+
+// Description returns the session's project and action, if recorded.
+func (i SessionItem) Description() string {
+	if md := i.Session.Metadata; md != nil && md.Project != "" {
+		return md.Project + " · " + md.Action
+	}
+	return utils.ShortenPath(i.Session.Path)
+}
+
+// FilterValue returns the string used for filtering sessions.
+func (i SessionItem) FilterValue() string {
+	return i.Session.ID + " " + i.Description()
+}
+
+// SessionDelegate renders session items with focus-aware styling.
+type SessionDelegate struct {
+	NormalStyle   lipgloss.Style
+	SelectedStyle lipgloss.Style
+}
+
+// NewSessionDelegate creates a new SessionDelegate with default styling.
+func NewSessionDelegate(styles Styles) SessionDelegate {
+	return SessionDelegate{
+		NormalStyle:   styles.DelegateNormal,
+		SelectedStyle: styles.DelegateSelected,
+	}
+}
+
+// Height returns the number of lines a single item occupies.
+func (d SessionDelegate) Height() int { return 1 }
+
+// Spacing returns the vertical spacing between items.
+func (d SessionDelegate) Spacing() int { return 0 }
+
+// Update handles logic for delegate updates.
+func (d SessionDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
+
+// Render paints the session item to the terminal.
+func (d SessionDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	item, ok := listItem.(SessionItem)
+	if !ok {
+		return
+	}
+
+	icon := lipgloss.NewStyle().Foreground(ColorRunning).Render(IconRunning)
+	indent := ""
+	if _, n := sessions.SplitInstance(item.Session.ID); n > 1 {
+		// Instances are listed right after their parent target
+		indent = "↳ "
+	}
+
+	var mainPart string
+	if index == m.Index() {
+		mainPart = d.SelectedStyle.Render(indent + IconRunning + " " + item.Session.ID)
+	} else {
+		mainPart = d.NormalStyle.Render(indent + icon + " " + lipgloss.NewStyle().Foreground(ColorText).Render(item.Session.ID))
+	}
+
+	avail := m.Width() - lipgloss.Width(mainPart) - 2
+	if desc := item.Description(); avail > 10 && desc != "" {
+		mainPart += " " + lipgloss.NewStyle().Foreground(ColorSubtext).Render(truncate(desc, avail))
+	}
+
+	_, _ = fmt.Fprint(w, mainPart)
+}
+
+// pendingOp is a session operation waiting for confirmation or input.
+type pendingOp int
+
+const (
+	pendingNone pendingOp = iota
+	pendingKill
+	pendingRestart
+	pendingRename
+	pendingParams
+	pendingWorktree
+	pendingTrust
+)
+
+// sessionOpMsg reports the outcome of a kill or rename.
+type sessionOpMsg struct {
+	status string
+	err    error
+}
+
+// restartMsg carries the target to attach after a session was restarted.
+type restartMsg struct {
+	target *sessions.Target
+	err    error
+}
+
+// toggleSessions switches between the locations picker and the sessions panel.
+func (m *Model) toggleSessions() []tea.Cmd {
+	if m.sessionManager == nil {
+		return nil
+	}
+	m.statusMsg = ""
+
+	if m.focus == FocusSessions {
+		m.setFocus(FocusLocations)
+		m.filterInput.SetValue(m.lastLeftFilter)
+		m.sessionList.SetFilterText("")
+		m.lastSessionFilter = ""
+		return []tea.Cmd{m.applyLocationFilter(), m.updateActions()}
+	}
+
+	if m.focus == FocusLocations {
+		m.lastLeftFilter = m.filterInput.Value()
+	}
+	m.setFocus(FocusSessions)
+	m.filterInput.SetValue("")
+	m.actions.SetFilterText("")
+	m.lastRightFilter = ""
+	m.sessionList.Select(0)
+	return []tea.Cmd{fetchSessions(m.sessionManager)}
+}
+
+// setSessionItems refreshes the sessions panel from the live session list.
+func (m *Model) setSessionItems() tea.Cmd {
+	items := make([]list.Item, len(m.liveSessions))
+	for i, s := range m.liveSessions {
+		items[i] = SessionItem{Session: s}
+	}
+	return m.sessionList.SetItems(items)
+}
+
+func (m *Model) selectedSession() (sessions.Session, bool) {
+	item, ok := m.sessionList.SelectedItem().(SessionItem)
+	if !ok {
+		return sessions.Session{}, false
+	}
+	return item.Session, true
+}
+
+// handleSessionOp starts a kill, restart or rename for the selected session.
+func (m *Model) handleSessionOp(op pendingOp) tea.Cmd {
+	sess, ok := m.selectedSession()
+	if !ok {
+		return nil
+	}
+
+	m.pending = op
+	m.pendingSession = sess.ID
+	m.statusMsg = ""
+
+	if op == pendingRename {
+		m.promptInput.Prompt = "Rename to: "
+		m.promptInput.SetValue(sess.ID)
+		m.promptInput.CursorEnd()
+		return m.promptInput.Focus()
+	}
+	return nil
+}
+
+// handlePendingKey processes input while an operation awaits confirmation.
+func (m *Model) handlePendingKey(msg tea.KeyMsg) tea.Cmd {
+	name := m.pendingSession
+	op := m.pending
+
+	if op == pendingParams {
+		return m.handleParamKey(msg)
+	}
+	if op == pendingWorktree {
+		return m.handleWorktreeKey(msg)
+	}
+	if op == pendingTrust {
+		return m.handleTrustKey(msg)
+	}
+
+	if op == pendingRename {
+		switch msg.String() {
+		case "enter":
+			m.clearPending()
+			return m.renameSession(name, strings.TrimSpace(m.promptInput.Value()))
+		case "esc", "ctrl+c":
+			m.clearPending()
+			return nil
+		}
+		var cmd tea.Cmd
+		m.promptInput, cmd = m.promptInput.Update(msg)
+		return cmd
+	}
+
+	m.clearPending()
+	if msg.String() != "y" && msg.String() != "Y" {
+		return nil
+	}
+	if op == pendingKill {
+		return m.killSession(name)
+	}
+	return m.restartSession(name)
+}
+
+func (m *Model) clearPending() {
+	m.pending = pendingNone
+	m.pendingSession = ""
+	m.params = nil
+	m.promptInput.Blur()
+}
+
+func (m *Model) killSession(name string) tea.Cmd {
+	mgr := m.sessionManager
+	return func() tea.Msg {
+		if err := mgr.Kill(name); err != nil {
+			return sessionOpMsg{err: err}
+		}
+		return sessionOpMsg{status: fmt.Sprintf("Session '%s' killed.", name)}
+	}
+}
+
+func (m *Model) renameSession(oldName, newName string) tea.Cmd {
+	mgr := m.sessionManager
+	return func() tea.Msg {
+		if err := mgr.Rename(oldName, newName); err != nil {
+			return sessionOpMsg{err: err}
+		}
+		return sessionOpMsg{status: fmt.Sprintf("Session '%s' renamed to '%s'.", oldName, newName)}
+	}
+}
+
+func (m *Model) restartSession(name string) tea.Cmd {
+	mgr := m.sessionManager
+	return func() tea.Msg {
+		target, err := mgr.Restart(name)
+		return restartMsg{target: target, err: err}
+	}
+}
+
+// startInBackground starts the highlighted action (or the location's default)
+// as a detached session, leaving the picker open.
+func (m *Model) startInBackground() tea.Cmd {
+	if m.sessionManager == nil || m.focus == FocusSessions {
+		return nil
+	}
+	sel, ok := m.locations.SelectedItem().(LocationItem)
+	if !ok {
+		return nil
+	}
+
+	act := defaultAction(sel.Location)
+	if m.focus == FocusActions {
+		actItem, ok := m.actions.SelectedItem().(ActionItem)
+		if !ok || !actItem.IsAction() {
+			return nil
+		}
+		act = &actItem.Action
+	}
+
+	if act == nil {
+		return m.startAction(sel.Location, "", nil)
+	}
+	return m.launchAction(sel.Location, *act, launchBackground)
+}
+
+// startAction starts an action as a detached session, leaving the picker open.
+func (m *Model) startAction(loc locations.Location, actionName string, params map[string]string) tea.Cmd {
+	target, err := m.sessionManager.ResolveParams(loc, actionName, env.DetectShell(), m.editor, params)
+	if err != nil {
+		m.statusMsg = "Error: " + err.Error()
+		return nil
+	}
+
+	mgr := m.sessionManager
+	return func() tea.Msg {
+		err := mgr.Start(*target)
+		switch {
+		case errors.Is(err, sessions.ErrRunning):
+			return sessionOpMsg{status: fmt.Sprintf("Session '%s' is already running.", target.Name)}
+		case err != nil:
+			return sessionOpMsg{err: err}
+		}
+		return sessionOpMsg{status: fmt.Sprintf("Started '%s' in the background.", target.Name)}
+	}
+}
+
+// handleSessionOpResult shows the outcome and refreshes the session list.
+func (m *Model) handleSessionOpResult(msg sessionOpMsg) tea.Cmd {
+	m.statusMsg = msg.status
+	if msg.err != nil {
+		m.statusMsg = "Error: " + msg.err.Error()
+	}
+	return fetchSessions(m.sessionManager)
+}
+
+// handleRestartResult attaches to the restarted session, or reports the failure.
+func (m *Model) handleRestartResult(msg restartMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = "Error: " + msg.err.Error()
+		return fetchSessions(m.sessionManager)
+	}
+	m.Result = SelectionResult{Target: msg.target}
+	m.quitting = true
+	return tea.Quit
+}
+
+// attachSelectedSession selects the highlighted live session for attachment.
+func (m *Model) attachSelectedSession() {
+	sess, ok := m.selectedSession()
+	if !ok {
+		return
+	}
+	target := sessions.Target{Name: sess.ID, Path: sess.Path, Backend: sess.Backend}
+	m.Result = SelectionResult{Target: &target}
+	m.quitting = true
+}
+
+// pendingPrompt returns the confirmation question for a pending operation.
+func (m *Model) pendingPrompt() string {
+	switch m.pending {
+	case pendingKill:
+		return fmt.Sprintf("Kill session '%s'? (y/N)", m.pendingSession)
+	case pendingRestart:
+		return fmt.Sprintf("Restart session '%s'? (y/N)", m.pendingSession)
+	}
+	return ""
+}
+
+// sessionDetails renders the metadata of the selected session.
+func (m *Model) sessionDetails() string {
+	sess, ok := m.selectedSession()
+	if !ok {
+		return "No live sessions"
+	}
+
+	fields := [][2]string{
+		{"Path", utils.ShortenPath(sess.Path)},
+		{"Backend", sess.Backend},
+	}
+	if md := sess.Metadata; md != nil {
+		attached := "never"
+		if !md.LastAttachedAt.IsZero() {
+			attached = md.LastAttachedAt.Format("2006-01-02 15:04")
+		}
+		fields = append(fields,
+			[2]string{"Project", md.Project},
+			[2]string{"Action", md.Action},
+			[2]string{"Host", md.Host},
+			[2]string{"Created", md.CreatedAt.Format("2006-01-02 15:04")},
+			[2]string{"Attached", attached},
+		)
+	}
+
+	label := lipgloss.NewStyle().Foreground(ColorSubtext)
+	width := m.layout.RightWidth - 12
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		lines[i] = label.Render(fmt.Sprintf("%-10s", f[0])) + truncate(f[1], width)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -14,6 +14,8 @@ var (
 	ColorHighlight = lipgloss.Color("#cba6f7") // Default selection color
 	ColorSubtext   = lipgloss.Color("240")     // Default subtext
 	ColorText      = lipgloss.Color("#ffffff") // Default text color
+	ColorRunning   = lipgloss.Color("#a6e3a1") // Live session indicator
+	ColorWarning   = lipgloss.Color("#f9e2af") // Provider warnings
 )
 
 // ApplyTheme overrides the default colors with values from the config.
@@ -40,6 +42,15 @@ var (
 	IconFolder  = "\uea83"
 	IconProject = "\uf503"
 	IconSearch  = "\uf002"
+	IconRunning = "\uf111"
+	IconLayout  = "\uf009"
+	IconGit     = "\ue702"
+	// IconBackground marks sessions started in the background (nf-fa-circle_o).
+	IconBackground = "\uf10c"
+	// IconDiscovered marks actions found in the project's task files (nf-fa-magic).
+	IconDiscovered = "\uf0d0"
+	// IconWarning marks providers whose locations could not be loaded (nf-fa-warning).
+	IconWarning = "\uf071"
 )
 
 func init() {
@@ -47,6 +58,12 @@ func init() {
 		IconFolder = "F"
 		IconProject = "P"
 		IconSearch = "S"
+		IconRunning = "*"
+		IconLayout = "L"
+		IconGit = "G"
+		IconBackground = "o"
+		IconDiscovered = "~"
+		IconWarning = "!"
 	}
 }
 
@@ -57,6 +74,7 @@ type Layout struct {
 	ContentWidth int
 	LeftWidth    int
 	RightWidth   int
+	PreviewWidth int // Zero when the preview pane is hidden
 	ListHeight   int
 }
 
@@ -65,25 +83,45 @@ type Styles struct {
 	Window           lipgloss.Style
 	LeftPanel        lipgloss.Style
 	RightPanel       lipgloss.Style
+	PreviewPanel     lipgloss.Style
 	SearchInput      lipgloss.Style
 	FocusedTitle     lipgloss.Style
 	NormalTitle      lipgloss.Style
 	Help             lipgloss.Style
+	Status           lipgloss.Style
 	DelegateNormal   lipgloss.Style
 	DelegateSelected lipgloss.Style
 }
 
 // DefaultLayout returns a Layout based on the provided terminal dimensions.
 func DefaultLayout(termWidth, termHeight int) Layout {
-	// Constrain content width: min 60, max 120
-	contentWidth := max(60, min(120, termWidth-4))
+	return NewLayout(termWidth, termHeight, 0)
+}
+
+// NewLayout returns a Layout that gives previewPercent of the content width
+// to the preview pane. A previewPercent of 0 hides the pane.
+func NewLayout(termWidth, termHeight, previewPercent int) Layout {
+	// Constrain content width: min 60, max 120 (or 200 with a preview)
+	maxWidth := 120
+	if previewPercent > 0 {
+		maxWidth = 200
+	}
+	contentWidth := max(60, min(maxWidth, termWidth-4))
+
+	// Preview pane takes its share first, accounting for its border
+	listWidth := contentWidth
+	previewWidth := 0
+	if previewPercent > 0 {
+		previewWidth = contentWidth * previewPercent / 100
+		listWidth = contentWidth - previewWidth - 2
+	}
 
 	// Panel widths: 60/40 split
-	leftWidth := contentWidth * 60 / 100
-	rightWidth := contentWidth - leftWidth - 3 // Account for border
+	leftWidth := listWidth * 60 / 100
+	rightWidth := listWidth - leftWidth - 3 // Account for border
 
-	// List height: leave room for search + borders + titles
-	listHeight := max(5, min(20, termHeight-11))
+	// List height: leave room for search + borders + titles + status
+	listHeight := max(5, min(20, termHeight-12))
 
 	return Layout{
 		Width:        termWidth,
@@ -91,6 +129,7 @@ func DefaultLayout(termWidth, termHeight int) Layout {
 		ContentWidth: contentWidth,
 		LeftWidth:    leftWidth,
 		RightWidth:   rightWidth,
+		PreviewWidth: previewWidth,
 		ListHeight:   listHeight,
 	}
 }
@@ -112,6 +151,14 @@ func DefaultStyles(l Layout) Styles {
 			Width(l.RightWidth).
 			PaddingLeft(2),
 
+		PreviewPanel: lipgloss.NewStyle().
+			Width(l.PreviewWidth).
+			Height(l.ListHeight+2).
+			MaxHeight(l.ListHeight+2).
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(ColorSubtext).
+			PaddingLeft(1),
+
 		SearchInput: lipgloss.NewStyle().
 			Width(l.ContentWidth-2). // Account for search box borders
 			Border(lipgloss.RoundedBorder()).
@@ -129,6 +176,9 @@ func DefaultStyles(l Layout) Styles {
 			Bold(true),
 
 		Help: lipgloss.NewStyle().
+			Foreground(ColorSubtext),
+
+		Status: lipgloss.NewStyle().
 			Foreground(ColorSubtext).
 			MarginTop(1),
 
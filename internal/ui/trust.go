@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+
+	"atelier-go/internal/config"
+	"atelier-go/internal/locations"
+	"atelier-go/internal/trust"
+	"atelier-go/internal/utils"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// trustStore returns the trust store, opening the default one on first use.
+func (m *Model) trustStore() (*trust.Store, error) {
+	if m.trust == nil {
+		store, err := trust.DefaultStore()
+		if err != nil {
+			return nil, err
+		}
+		m.trust = store
+	}
+	return m.trust, nil
+}
+
+// isTrusted reports whether an action may run without asking. Actions from
+// the user's own config always may; actions from a project file only once
+// that exact version of the file has been trusted.
+func (m *Model) isTrusted(act config.Action) bool {
+	if act.Origin == "" {
+		return true
+	}
+	store, err := m.trustStore()
+	if err != nil {
+		return false
+	}
+	ok, err := store.IsTrusted(act.Origin, act.OriginHash)
+	return err == nil && ok
+}
+
+// needsPrompt reports whether launching an action asks for anything first.
+func (m *Model) needsPrompt(act config.Action) bool {
+	return len(act.Params) > 0 || !m.isTrusted(act)
+}
+
+// promptTrust asks whether to run an action from an untrusted project file.
+func (m *Model) promptTrust(loc locations.Location, act config.Action, mode launchMode) tea.Cmd {
+	m.params = &paramPrompt{location: loc, action: act, mode: mode, values: make(map[string]string)}
+	m.pending = pendingTrust
+	m.statusMsg = ""
+	return nil
+}
+
+// handleTrustKey trusts the project file on "y" and continues the launch.
+func (m *Model) handleTrustKey(msg tea.KeyMsg) tea.Cmd {
+	prompt := m.params
+	if msg.String() != "y" && msg.String() != "Y" {
+		m.clearPending()
+		return nil
+	}
+
+	store, err := m.trustStore()
+	if err == nil {
+		err = store.Trust(prompt.action.Origin, prompt.action.OriginHash)
+	}
+	if err != nil {
+		m.clearPending()
+		m.statusMsg = "Error: " + err.Error()
+		return nil
+	}
+
+	if len(prompt.action.Params) > 0 {
+		m.pending = pendingParams
+		return m.showParam()
+	}
+	m.clearPending()
+	return m.finishLaunch(prompt.location, prompt.action, nil, prompt.mode)
+}
+
+// trustPrompt returns the question asked before running an untrusted action.
+func (m *Model) trustPrompt() string {
+	return fmt.Sprintf("Run '%s' from %s? The file is new or has changed. (y/N)", m.params.action.Name, m.params.action.Origin)
+}
+
+// untrustedPane returns the first action of a layout that comes from an
+// untrusted project file, or nil when every pane may run.
+func (m *Model) untrustedPane(loc locations.Location, layout config.Layout) *config.Action {
+	for _, tab := range layout.Tabs {
+		for _, pane := range tab.Panes {
+			for i, act := range loc.Actions {
+				if utils.Sanitize(act.Name) == utils.Sanitize(pane) && !m.isTrusted(act) {
+					return &loc.Actions[i]
+				}
+			}
+		}
+	}
+	return nil
+}
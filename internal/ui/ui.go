@@ -6,6 +6,7 @@ import (
 	"atelier-go/internal/env"
 	"atelier-go/internal/locations"
 	"atelier-go/internal/sessions"
+	"atelier-go/internal/terminal"
 	"atelier-go/internal/utils"
 	"context"
 	"fmt"
@@ -20,36 +21,42 @@ func Run(ctx context.Context, mgr *locations.Manager, cfg *config.Config, client
 	// Apply custom theme from config
 	ApplyTheme(cfg.Theme)
 
+	sessionManager, err := sessions.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Try to recover session if client ID is provided
 	if clientID != "" {
-		if recovered := tryRecover(clientID); recovered {
+		if recovered := tryRecover(sessionManager, clientID); recovered {
 			return nil
 		}
 	}
 
 	// Fetch locations
-	locs, err := mgr.GetAll(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch locations: %w", err)
-	}
-
+	locs, failed := mgr.GetAll(ctx)
 	if len(locs) == 0 {
+		for _, f := range failed {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", f)
+		}
 		fmt.Println("No projects or recent directories found.")
 		return nil
 	}
 
 	// Interactive selection
-	result, err := runSelection(locs, cfg)
+	result, layout, err := runSelection(locs, failed, cfg, sessionManager)
 	if err != nil {
 		return err
 	}
+	if layout != nil {
+		return openLayout(cfg, *layout)
+	}
 	if result == nil {
 		// User cancelled
 		return nil
 	}
 
 	// Attach to session
-	sessionManager := sessions.NewManager()
 	statusPrefix := ""
 	if utils.IsSSH() {
 		statusPrefix = utils.IconSSH + " "
@@ -63,7 +70,7 @@ func Run(ctx context.Context, mgr *locations.Manager, cfg *config.Config, client
 	}
 
 	fmt.Printf("Attaching to %ssession '%s' in %s\n", statusPrefix, result.Name, result.Path)
-	if err := sessionManager.Attach(result.Name, result.Path, result.Command...); err != nil {
+	if err := sessionManager.Attach(*result); err != nil {
 		return fmt.Errorf("error attaching to session: %w", err)
 	}
 
@@ -77,54 +84,98 @@ func Run(ctx context.Context, mgr *locations.Manager, cfg *config.Config, client
 	return nil
 }
 
-// runSelection executes the TUI and returns a session target
-func runSelection(locs []locations.Location, cfg *config.Config) (*sessions.Target, error) {
-	model := NewModel(locs)
+// layoutSelection is a layout chosen in the TUI, with the location it belongs to.
+type layoutSelection struct {
+	location locations.Location
+	layout   config.Layout
+}
+
+// openLayout opens the chosen layout in new terminal tabs and splits.
+func openLayout(cfg *config.Config, sel layoutSelection) error {
+	driver, n, err := terminal.OpenLocation(cfg.Terminal, sel.location, sel.layout)
+	if err != nil {
+		return fmt.Errorf("error opening layout: %w", err)
+	}
+	fmt.Printf("Opened layout '%s' with %d pane(s) in %s.\n", sel.layout.Name, n, driver.Name())
+	return nil
+}
+
+// runSelection executes the TUI and returns a session target, or the layout to open
+func runSelection(locs []locations.Location, failed []locations.ProviderError, cfg *config.Config, sessionManager *sessions.Manager) (*sessions.Target, *layoutSelection, error) {
+	model := NewModel(locs, sessionManager)
+	model.editor = cfg.GetEditor()
+	model.warnings = failed
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	finalModel, err := p.Run()
 	if err != nil {
-		return nil, fmt.Errorf("TUI error: %w", err)
+		return nil, nil, fmt.Errorf("TUI error: %w", err)
 	}
 
 	m, ok := finalModel.(*Model)
 	if !ok {
-		return nil, fmt.Errorf("unexpected model type")
+		return nil, nil, fmt.Errorf("unexpected model type")
+	}
+
+	if m.Result.Target != nil {
+		// An existing session was chosen from the sessions panel
+		return m.Result.Target, nil, nil
 	}
 
 	if m.Result.Canceled || m.Result.Location == nil {
-		return nil, nil // User cancelled
+		return nil, nil, nil // User cancelled
+	}
+
+	if m.Result.Layout != nil {
+		return nil, &layoutSelection{location: *m.Result.Location, layout: *m.Result.Layout}, nil
 	}
 
 	// Resolve selection to session target
 	shell := env.DetectShell()
-	sessionManager := sessions.NewManager()
 
 	actionName := ""
 	if m.Result.Action != nil {
 		actionName = m.Result.Action.Name
 	}
 
-	return sessionManager.Resolve(*m.Result.Location, actionName, shell, cfg.GetEditor())
+	target, err := sessionManager.ResolveParams(*m.Result.Location, actionName, shell, cfg.GetEditor(), m.Result.Params)
+	if err != nil || !m.Result.NewInstance {
+		return target, nil, err
+	}
+
+	instance, err := sessionManager.NewInstance(*target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &instance, nil, nil
 }
 
 // tryRecover attempts to re-attach to a previously active session for the client.
-func tryRecover(clientID string) bool {
+func tryRecover(mgr *sessions.Manager, clientID string) bool {
 	sessionID, err := sessions.LoadState(clientID)
 	if err != nil || sessionID == "" {
 		return false
 	}
 
-	mgr := sessions.NewManager()
 	if !mgr.SessionExists(sessionID) {
 		// Session no longer exists, clean up stale state
 		_ = sessions.ClearState(clientID)
 		return false
 	}
 
-	// Session exists, re-attach directly
-	fmt.Printf("%s Recovering session '%s'...\n", utils.IconSSH, sessionID)
-	if err := mgr.Attach(sessionID, ""); err != nil {
+	// Session exists, re-attach directly using what was recorded at creation
+	target := sessions.Target{Name: sessionID}
+	label := fmt.Sprintf("'%s'", sessionID)
+	if md, err := mgr.Metadata(sessionID); err == nil && md != nil {
+		target.Path = md.Path
+		target.Backend = md.Backend
+		if md.Project != "" {
+			label = fmt.Sprintf("'%s' (%s: %s)", sessionID, md.Project, md.Action)
+		}
+	}
+
+	fmt.Printf("%s Recovering session %s...\n", utils.IconSSH, label)
+	if err := mgr.Attach(target); err != nil {
 		fmt.Fprintf(os.Stderr, "error during recovery: %v\n", err)
 		return false
 	}
@@ -1,13 +1,18 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 func (m *Model) updateDimensions() {
 	m.locations.SetSize(m.layout.LeftWidth, m.layout.ListHeight)
 	m.actions.SetSize(m.layout.RightWidth, m.layout.ListHeight)
+	m.sessionList.SetSize(m.layout.LeftWidth, m.layout.ListHeight)
 	m.filterInput.Width = m.layout.ContentWidth - 10
+	m.promptInput.Width = m.layout.ContentWidth - 16
 
 	// Update delegate styles
 	m.locationsDelegate.NormalStyle = m.styles.DelegateNormal
@@ -17,6 +22,10 @@ func (m *Model) updateDimensions() {
 	m.actionsDelegate.NormalStyle = m.styles.DelegateNormal
 	m.actionsDelegate.SelectedStyle = m.styles.DelegateSelected
 	m.actions.SetDelegate(m.actionsDelegate)
+
+	m.sessionsDelegate.NormalStyle = m.styles.DelegateNormal
+	m.sessionsDelegate.SelectedStyle = m.styles.DelegateSelected
+	m.sessionList.SetDelegate(m.sessionsDelegate)
 }
 
 // View renders the TUI to the terminal.
@@ -26,7 +35,38 @@ func (m *Model) View() string {
 	}
 
 	search := m.styles.SearchInput.Render(m.filterInput.View())
+	if m.pending == pendingRename || m.pending == pendingParams || m.pending == pendingWorktree {
+		search = m.styles.SearchInput.Render(m.promptInput.View())
+	}
 
+	var panels, help string
+	if m.focus == FocusSessions {
+		panels = m.sessionsView()
+		help = m.styles.Help.Render("Enter:Attach • Ctrl+X:Kill • Ctrl+R:Restart • Ctrl+E:Rename • Ctrl+O/Esc:Back • Ctrl+C:Quit")
+	} else {
+		panels = m.locationsView()
+		help = m.styles.Help.Render("Enter:Select • Tab:Actions • Alt+Enter:Default • Ctrl+T:New • Ctrl+B:Background • Ctrl+O:Sessions • Ctrl+V:Preview • Alt+H/L:Resize • Esc:Back • Ctrl+C:Quit")
+	}
+
+	inner := lipgloss.JoinVertical(
+		lipgloss.Left,
+		search,
+		panels,
+		m.styles.Status.Render(m.statusLine()),
+		help,
+	)
+
+	content := m.styles.Window.Render(inner)
+
+	if m.layout.Width == 0 {
+		return content
+	}
+
+	return lipgloss.Place(m.layout.Width, m.layout.Height, lipgloss.Center, lipgloss.Center, content)
+}
+
+// locationsView renders the locations and actions panels.
+func (m *Model) locationsView() string {
 	locationTitle := m.styles.NormalTitle.Render("PROJECTS & LOCATIONS")
 	if m.focus == FocusLocations {
 		locationTitle = m.styles.FocusedTitle.Render("SELECT LOCATION")
@@ -59,20 +99,91 @@ func (m *Model) View() string {
 		lipgloss.JoinVertical(lipgloss.Left, actionTitle, "", rightView),
 	)
 
-	help := m.styles.Help.Render("Enter:Select • Tab:Actions • Alt+Enter:Default Action • Esc:Back • Ctrl+C:Quit")
+	if m.showPreview {
+		return lipgloss.JoinHorizontal(lipgloss.Top, leftView, right, m.previewView())
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftView, right)
+}
 
-	inner := lipgloss.JoinVertical(
-		lipgloss.Left,
-		search,
-		lipgloss.JoinHorizontal(lipgloss.Top, leftView, right),
-		help,
+// sessionsView renders the live sessions panel and the selected session's details.
+func (m *Model) sessionsView() string {
+	title := m.styles.FocusedTitle.Render("LIVE SESSIONS")
+
+	var leftView string
+	switch {
+	case !m.sessionsLoaded:
+		leftView = "Loading sessions..."
+	case len(m.sessionList.VisibleItems()) == 0:
+		leftView = "No sessions match your search"
+	default:
+		leftView = m.sessionList.View()
+	}
+
+	left := m.styles.LeftPanel.Render(
+		lipgloss.JoinVertical(lipgloss.Left, title, "", leftView),
+	)
+	right := m.styles.RightPanel.Render(
+		lipgloss.JoinVertical(lipgloss.Left, m.styles.NormalTitle.Render("DETAILS"), "", m.sessionDetails()),
 	)
 
-	content := m.styles.Window.Render(inner)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+}
 
-	if m.layout.Width == 0 {
-		return content
+// statusLine shows the session status followed by provider warnings, which
+// stay visible whatever else the line shows.
+func (m *Model) statusLine() string {
+	width := m.layout.ContentWidth - 4
+	warning := m.warningText()
+	if warning == "" {
+		return m.sessionStatus(width)
 	}
 
-	return lipgloss.Place(m.layout.Width, m.layout.Height, lipgloss.Center, lipgloss.Center, content)
+	// The status gets whatever the warning leaves, but at least half the line
+	status := m.sessionStatus(max(width-lipgloss.Width(warning)-4, width/2))
+	if status == "" {
+		return m.warningLine(warning, width)
+	}
+	return status + "  " + m.warningLine(warning, width-lipgloss.Width(status)-2)
+}
+
+// sessionStatus shows pending confirmations, the last operation's outcome,
+// or a summary of live sessions once they have been fetched.
+func (m *Model) sessionStatus(width int) string {
+	switch {
+	case m.pending == pendingKill || m.pending == pendingRestart:
+		return lipgloss.NewStyle().Foreground(ColorHighlight).Bold(true).Render(truncate(m.pendingPrompt(), width))
+	case m.pending == pendingTrust:
+		return lipgloss.NewStyle().Foreground(ColorHighlight).Bold(true).Render(truncate(m.trustPrompt(), width))
+	case m.statusMsg != "":
+		return truncate(m.statusMsg, width)
+	case m.pending == pendingParams:
+		return truncate(m.paramHint(), width)
+	case m.sessionManager == nil:
+		return ""
+	case !m.sessionsLoaded:
+		return "Loading sessions..."
+	case m.sessionsErr != nil:
+		return "Sessions unavailable: " + truncate(m.sessionsErr.Error(), width-22)
+	}
+
+	n := len(m.liveSessions)
+	label := "live sessions"
+	if n == 1 {
+		label = "live session"
+	}
+	return lipgloss.NewStyle().Foreground(ColorRunning).Render(IconRunning) + fmt.Sprintf(" %d %s", n, label)
+}
+
+// warningText lists the providers whose locations could not be loaded.
+func (m *Model) warningText() string {
+	msgs := make([]string, len(m.warnings))
+	for i, w := range m.warnings {
+		msgs[i] = w.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// warningLine renders warning text within width.
+func (m *Model) warningLine(text string, width int) string {
+	return lipgloss.NewStyle().Foreground(ColorWarning).Render(IconWarning + " " + truncate(text, width-2))
 }
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"atelier-go/internal/locations"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// worktreeMsg carries the location of a newly created worktree.
+type worktreeMsg struct {
+	location locations.Location
+	err      error
+}
+
+// promptWorktree asks for the branch of a new worktree of the location's repository.
+func (m *Model) promptWorktree(loc locations.Location) tea.Cmd {
+	m.pending = pendingWorktree
+	m.worktreeFrom = loc.Path
+	m.statusMsg = ""
+	m.promptInput.Prompt = "Branch: "
+	m.promptInput.SetValue("")
+	return m.promptInput.Focus()
+}
+
+// handleWorktreeKey processes input while asking for the worktree's branch.
+func (m *Model) handleWorktreeKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.clearPending()
+		return nil
+	case "enter":
+		branch := strings.TrimSpace(m.promptInput.Value())
+		path := m.worktreeFrom
+		m.clearPending()
+		if branch == "" {
+			return nil
+		}
+		return m.createWorktree(path, branch)
+	}
+
+	var cmd tea.Cmd
+	m.promptInput, cmd = m.promptInput.Update(msg)
+	return cmd
+}
+
+// createWorktree adds a worktree to the repository of the location at path.
+// Worktrees created from a worktree are listed under the same repository.
+func (m *Model) createWorktree(path, branch string) tea.Cmd {
+	parent, ok := m.findLocation(path)
+	if !ok {
+		return nil
+	}
+	if parent.Parent != "" {
+		if p, ok := m.findLocation(parent.Parent); ok {
+			parent = p
+		}
+	}
+
+	m.statusMsg = "Creating worktree for '" + branch + "'..."
+	return func() tea.Msg {
+		loc, err := locations.CreateWorktree(context.Background(), parent, branch)
+		return worktreeMsg{location: loc, err: err}
+	}
+}
+
+// findLocation returns the location with the given path.
+func (m *Model) findLocation(path string) (locations.Location, bool) {
+	for _, loc := range m.allLocations {
+		if loc.Path == path {
+			return loc, true
+		}
+	}
+	return locations.Location{}, false
+}
+
+// handleWorktreeResult opens a session in the new worktree, or reports the failure.
+func (m *Model) handleWorktreeResult(msg worktreeMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = "Error: " + msg.err.Error()
+		return nil
+	}
+	loc := msg.location
+	if act := defaultAction(loc); act != nil && m.needsPrompt(*act) {
+		return m.launchAction(loc, *act, launchAttach)
+	}
+	m.Result = SelectionResult{Location: &loc}
+	m.quitting = true
+	return tea.Quit
+}
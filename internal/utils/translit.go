@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transliterate converts a string to its closest ASCII spelling.
+// Accents are stripped, and Cyrillic, Greek and Japanese kana are romanized.
+// Characters without a known romanization (e.g. kanji) are dropped.
+func Transliterate(s string) string {
+	var b strings.Builder
+	runes := []rune(norm.NFKD.String(s))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r < unicode.MaxASCII:
+			b.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+			// Combining marks left over from decomposing accented letters
+		case isKana(r):
+			n := writeKana(&b, runes[i:])
+			i += n - 1
+		default:
+			if t, ok := translitTable[unicode.ToLower(r)]; ok {
+				if unicode.IsUpper(r) && t != "" {
+					t = strings.ToUpper(t[:1]) + t[1:]
+				}
+				b.WriteString(t)
+			}
+		}
+	}
+	return b.String()
+}
+
+// translitTable romanizes letters that do not decompose to ASCII.
+var translitTable = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l", 'þ': "th", 'ı': "i",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh",
+	'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// kanaTable romanizes hiragana (Hepburn). Katakana is folded to hiragana first.
+var kanaTable = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n", "ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o", "ゃ": "ya", "ゅ": "yu", "ょ": "yo",
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo", "ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "じゃ": "ja", "じゅ": "ju", "じょ": "jo",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo", "びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo", "みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+}
+
+func isKana(r rune) bool {
+	return (r >= 0x3041 && r <= 0x3096) || (r >= 0x30A1 && r <= 0x30FC)
+}
+
+// toHiragana folds a katakana rune to hiragana.
+func toHiragana(r rune) rune {
+	if r >= 0x30A1 && r <= 0x30F6 {
+		return r - 0x60
+	}
+	return r
+}
+
+// writeKana romanizes the kana at the start of runes and returns how many runes it consumed.
+// NFKD splits voiced kana into base + combining mark, so marks are recomposed first.
+func writeKana(b *strings.Builder, runes []rune) int {
+	consumed := 0
+	next := func() string {
+		if consumed >= len(runes) || !isKana(runes[consumed]) {
+			return ""
+		}
+		r := toHiragana(runes[consumed])
+		consumed++
+		s := string(r)
+		if consumed < len(runes) && (runes[consumed] == 0x3099 || runes[consumed] == 0x309A) {
+			s = norm.NFC.String(s + string(runes[consumed]))
+			consumed++
+		}
+		return s
+	}
+
+	kana := next()
+	switch kana {
+	case "ー":
+		// Long vowel mark: repeat the previous vowel
+		if str := b.String(); str != "" {
+			b.WriteByte(str[len(str)-1])
+		}
+		return consumed
+	case "っ":
+		// Small tsu doubles the following consonant
+		mark := consumed
+		following := next()
+		if r, ok := kanaTable[following]; ok {
+			b.WriteByte(r[0])
+		}
+		consumed = mark
+		return consumed
+	}
+
+	mark := consumed
+	if small := next(); small != "" {
+		if r, ok := kanaTable[kana+small]; ok {
+			b.WriteString(r)
+			return consumed
+		}
+	}
+	consumed = mark
+	b.WriteString(kanaTable[kana])
+	return consumed
+}
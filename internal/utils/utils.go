@@ -2,6 +2,8 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -14,10 +16,46 @@ import (
 var sanitizeRegex = regexp.MustCompile(`[^a-z0-9]+`)
 
 // Sanitize cleans a string to be used as a session name component.
+// Non-ASCII text is transliterated; when nothing usable remains (e.g. a name
+// written in kanji), a short hash of the original string is used instead.
 func Sanitize(s string) string {
-	s = strings.ToLower(s)
-	s = sanitizeRegex.ReplaceAllString(s, "-")
-	return strings.Trim(s, "-")
+	cleaned := strings.ToLower(Transliterate(s))
+	cleaned = sanitizeRegex.ReplaceAllString(cleaned, "-")
+	cleaned = strings.Trim(cleaned, "-")
+	if cleaned == "" && strings.TrimSpace(s) != "" {
+		return ShortHash(s)
+	}
+	return cleaned
+}
+
+// ShortHash returns a short, stable hex digest of s.
+func ShortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:3])
+}
+
+// ShellJoin quotes each argument for a POSIX shell and joins them with spaces.
+// It is used where a command must be passed as a single string.
+func ShellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a != "" && strings.IndexFunc(a, needsQuote) == -1 {
+			quoted[i] = a
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+func needsQuote(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	case strings.ContainsRune("-_./:=@%+,", r):
+		return false
+	}
+	return true
 }
 
 // NewTableWriter creates a configured tabwriter for consistent table output.
@@ -121,6 +159,12 @@ func SetTerminalTitle(title string) {
 // GetStateDir returns the XDG state directory for atelier-go.
 // Creates the directory if it doesn't exist.
 func GetStateDir() (string, error) {
+	return GetStateSubdir("sessions")
+}
+
+// GetStateSubdir returns a named directory under the atelier-go XDG state directory.
+// Creates the directory if it doesn't exist.
+func GetStateSubdir(name string) (string, error) {
 	stateHome := os.Getenv("XDG_STATE_HOME")
 	if stateHome == "" {
 		home, err := os.UserHomeDir()
@@ -129,7 +173,7 @@ func GetStateDir() (string, error) {
 		}
 		stateHome = filepath.Join(home, ".local", "state")
 	}
-	dir := filepath.Join(stateHome, "atelier-go", "sessions")
+	dir := filepath.Join(stateHome, "atelier-go", name)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return "", err
 	}
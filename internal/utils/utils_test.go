@@ -0,0 +1,69 @@
+package utils
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"My App", "my-app"},
+		{"  --API/v2--  ", "api-v2"},
+		{"Café Déjà Vu", "cafe-deja-vu"},
+		{"Straße", "strasse"},
+		{"Проект Дом", "proekt-dom"},
+		{"Ελληνικά", "ellinika"},
+		{"さくら", "sakura"},
+		{"ガッコウ", "gakkou"},
+		{"トーキョー", "tookyoo"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Sanitize(tt.in); got != tt.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSanitize_HashFallback(t *testing.T) {
+	a, b := Sanitize("東京"), Sanitize("大阪")
+	if len(a) != 6 || a == b {
+		t.Errorf("expected distinct 6-character hashes, got %q and %q", a, b)
+	}
+	if Sanitize("東京") != a {
+		t.Errorf("expected hash fallback to be stable")
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	got := ShellJoin([]string{"/bin/zsh", "-l", "-c", "echo 'hi' && make"})
+	expected := `/bin/zsh -l -c 'echo '\''hi'\'' && make'`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"/home/me/work/**/services/*", "/home/me/work/acme/services/api", true},
+		{"/home/me/work/**/services/*", "/home/me/work/services/api", true},
+		{"/home/me/work/**/services/*", "/home/me/work/a/b/services/api", true},
+		{"/home/me/work/**/services/*", "/home/me/work/acme/services/api/cmd", false},
+		{"/home/me/work/**", "/home/me/work/anything/below", true},
+		{"/home/me/*/src", "/home/me/a/b/src", false},
+		{"/home/me/src", "/home/me/src", true},
+	}
+	for _, tt := range tests {
+		got, err := MatchGlob(tt.pattern, tt.name)
+		if err != nil {
+			t.Fatalf("MatchGlob(%q) failed: %v", tt.pattern, err)
+		}
+		if got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}